@@ -0,0 +1,103 @@
+package account
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"net/url"
+	"strings"
+	"time"
+	"unicode/utf8"
+	"wikirace/pkg/helper"
+	"wikirace/pkg/stderror"
+)
+
+const (
+	idLength       = 16
+	tokenLength    = 32
+	maxNameLength  = 32
+	maxAvatarBytes = 512
+)
+
+// Account is a persistent player identity that stats, ratings and match history are attached to.
+// The ID is public and shown to other players, the secret token proves who owns the account.
+type Account struct {
+	ID          string    `json:"id"`
+	TokenHash   string    `json:"-"` // SHA-256 of the token, the token itself is only returned when the account is created
+	DisplayName string    `json:"displayName"`
+	AvatarURL   string    `json:"avatarURL"`
+	CreatedAt   time.Time `json:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
+
+// New validates the profile and creates an account with a fresh ID, it returns the account and its secret token
+func New(displayName, avatarURL string) (*Account, string, error) {
+	displayName, avatarURL, err := validateProfile(displayName, avatarURL)
+	if err != nil {
+		return nil, "", err
+	}
+	id, err := helper.GenerateRandomCode(idLength)
+	if err != nil {
+		return nil, "", err
+	}
+	token, err := helper.GenerateRandomCode(tokenLength)
+	if err != nil {
+		return nil, "", err
+	}
+	now := time.Now()
+	return &Account{
+		ID:          id,
+		TokenHash:   hashToken(token),
+		DisplayName: displayName,
+		AvatarURL:   avatarURL,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}, token, nil
+}
+
+// CheckToken verifies the secret token of the account
+func (a *Account) CheckToken(token string) error {
+	if token == "" || a.TokenHash == "" ||
+		subtle.ConstantTimeCompare([]byte(hashToken(token)), []byte(a.TokenHash)) != 1 {
+		return stderror.New(stderror.ErrAccountToken, errors.New("invalid account token, id: "+a.ID))
+	}
+	return nil
+}
+
+// hashToken returns the hex SHA-256 of a token, tokens are random so they need no salt
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// SetProfile validates and updates the display name and avatar of an account
+func (a *Account) SetProfile(displayName, avatarURL string) error {
+	displayName, avatarURL, err := validateProfile(displayName, avatarURL)
+	if err != nil {
+		return err
+	}
+	a.DisplayName = displayName
+	a.AvatarURL = avatarURL
+	a.UpdatedAt = time.Now()
+	return nil
+}
+
+// validateProfile trims and checks a display name and an optional avatar URL
+func validateProfile(displayName, avatarURL string) (string, string, error) {
+	displayName = strings.TrimSpace(displayName)
+	avatarURL = strings.TrimSpace(avatarURL)
+	if displayName == "" {
+		return "", "", stderror.New(stderror.ErrValidation, errors.New("display name is required"))
+	}
+	if utf8.RuneCountInString(displayName) > maxNameLength {
+		return "", "", stderror.New(stderror.ErrValidation, errors.New("display name is too long"))
+	}
+	if avatarURL != "" {
+		u, err := url.Parse(avatarURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" || len(avatarURL) > maxAvatarBytes {
+			return "", "", stderror.New(stderror.ErrValidation, errors.New("invalid avatar url: "+avatarURL))
+		}
+	}
+	return displayName, avatarURL, nil
+}
@@ -0,0 +1,53 @@
+package account
+
+import (
+	"errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"wikirace/pkg/logger"
+	"wikirace/pkg/mongodb"
+	"wikirace/pkg/stderror"
+)
+
+// MongoStore is a Store backed by the accounts collection
+type MongoStore struct {
+	collection *mongo.Collection
+}
+
+// NewMongoStore creates a MongoStore using the given client
+func NewMongoStore(db *mongo.Client) *MongoStore {
+	return &MongoStore{
+		collection: mongodb.GetCollection(db, "wikirace", "accounts"),
+	}
+}
+
+func (s *MongoStore) Create(a *Account) error {
+	_, err := s.collection.InsertOne(nil, a)
+	return err
+}
+
+func (s *MongoStore) Get(id string) (*Account, error) {
+	filter := bson.M{"id": id}
+	a := Account{}
+	err := s.collection.FindOne(nil, filter).Decode(&a)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			logger.Debugf("account not found: %v", id)
+			return nil, stderror.New(stderror.ErrAccountNotFound, errors.New("account not found, id: "+id))
+		}
+		return nil, err
+	}
+	return &a, nil
+}
+
+func (s *MongoStore) Update(a *Account) error {
+	filter := bson.M{"id": a.ID}
+	result, err := s.collection.ReplaceOne(nil, filter, a)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return stderror.New(stderror.ErrAccountNotFound, errors.New("account not found, id: "+a.ID))
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package account
+
+import (
+	"errors"
+	"sync"
+	"wikirace/pkg/stderror"
+)
+
+// Store persists accounts
+type Store interface {
+	// Create stores a new account
+	Create(a *Account) error
+	// Get returns an account by its ID
+	Get(id string) (*Account, error)
+	// Update replaces a stored account
+	Update(a *Account) error
+}
+
+// MemoryStore is an in-memory Store, used for tests and local development
+type MemoryStore struct {
+	mu       sync.RWMutex
+	accounts map[string]Account
+}
+
+// NewMemoryStore creates an empty MemoryStore
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		accounts: make(map[string]Account),
+	}
+}
+
+func (s *MemoryStore) Create(a *Account) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.accounts[a.ID]; ok {
+		return errors.New("account already exists, id: " + a.ID)
+	}
+	s.accounts[a.ID] = *a
+	return nil
+}
+
+func (s *MemoryStore) Get(id string) (*Account, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	a, ok := s.accounts[id]
+	if !ok {
+		return nil, stderror.New(stderror.ErrAccountNotFound, errors.New("account not found, id: "+id))
+	}
+	return &a, nil
+}
+
+func (s *MemoryStore) Update(a *Account) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.accounts[a.ID]; !ok {
+		return stderror.New(stderror.ErrAccountNotFound, errors.New("account not found, id: "+a.ID))
+	}
+	s.accounts[a.ID] = *a
+	return nil
+}
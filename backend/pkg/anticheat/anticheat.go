@@ -0,0 +1,149 @@
+package anticheat
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"wikirace/pkg/match"
+	"wikirace/pkg/wiki"
+)
+
+// Links gives the analysis access to the link graph of the wiki a match was played on,
+// *wiki.Service implements it
+type Links interface {
+	Article(ctx context.Context, title string) (*wiki.Article, error)
+	// Canonicalize returns the canonical title of an article, with redirects resolved
+	Canonicalize(ctx context.Context, title string) string
+}
+
+// Rules configure which moves are suspicious
+type Rules struct {
+	MinMove    time.Duration // moves faster than this are flagged, zero disables the speed check
+	CheckLinks bool          // flag moves to articles the previous article does not link to
+}
+
+// Analyze flags the suspicious moves of the human participants of a match.
+// Articles that cannot be read are skipped, so a failing wiki never flags a player.
+func Analyze(ctx context.Context, m *match.Match, links Links, rules Rules) []match.Flag {
+	flags := make([]match.Flag, 0)
+	for _, p := range m.Players {
+		if p.Bot != "" {
+			continue
+		}
+		flags = append(flags, checkTimes(m, p, rules)...)
+		if rules.CheckLinks && links != nil {
+			flags = append(flags, checkLinks(ctx, m, p, links)...)
+		}
+	}
+	return flags
+}
+
+// checkTimes flags moves whose times are out of order, outside the round or too fast.
+// Paths recorded without move times, e.g. of rounds started before move times were recorded, are not checked.
+func checkTimes(m *match.Match, p match.Participant, rules Rules) []match.Flag {
+	flags := make([]match.Flag, 0)
+	if len(p.PathTimes) < len(p.Paths) {
+		return flags
+	}
+	if len(p.PathTimes) > len(p.Paths) {
+		return append(flags, match.Flag{
+			PlayerID: p.ID,
+			Kind:     match.FlagOutOfOrder,
+			Detail:   fmt.Sprintf("%d articles but %d move times", len(p.Paths), len(p.PathTimes)),
+		})
+	}
+	for i, t := range p.PathTimes {
+		flag := match.Flag{PlayerID: p.ID, Move: i, Article: p.Paths[i]}
+		switch {
+		case t.Before(m.StartTime) || (!m.EndTime.IsZero() && t.After(m.EndTime)):
+			flag.Kind = match.FlagOutOfOrder
+			flag.Detail = "move outside of the round"
+		case i > 0 && t.Before(p.PathTimes[i-1]):
+			flag.Kind = match.FlagOutOfOrder
+			flag.Detail = "move submitted before the previous one"
+		case i > 0 && rules.MinMove > 0 && t.Sub(p.PathTimes[i-1]) < rules.MinMove:
+			flag.Kind = match.FlagSpeed
+			flag.Detail = fmt.Sprintf("%v after the previous move", t.Sub(p.PathTimes[i-1]))
+		default:
+			continue
+		}
+		flags = append(flags, flag)
+	}
+	return flags
+}
+
+// checkLinks flags moves to articles that are not linked from the previous article.
+// Going back to an article reached before is allowed, like pressing the back button.
+func checkLinks(ctx context.Context, m *match.Match, p match.Participant, links Links) []match.Flag {
+	flags := make([]match.Flag, 0)
+	previous := m.StartArticle
+	visited := map[string]bool{m.StartArticle: true}
+	for i, article := range p.Paths {
+		if i == 0 && article == m.StartArticle {
+			continue
+		}
+		if !visited[article] {
+			linked, ok := isLinked(ctx, links, previous, article)
+			if ok && !linked {
+				flag := match.Flag{PlayerID: p.ID, Kind: match.FlagNotLinked, Move: i, Article: article,
+					Detail: "not linked from " + previous}
+				if article == m.TargetArticle {
+					flag.Kind = match.FlagTargetJump
+				}
+				flags = append(flags, flag)
+			}
+		}
+		visited[article] = true
+		previous = article
+	}
+	return flags
+}
+
+// isLinked reports whether from links to the article to, ok is false if it could not be decided
+func isLinked(ctx context.Context, links Links, from, to string) (linked bool, ok bool) {
+	article, err := links.Article(ctx, from)
+	if err != nil {
+		return false, false
+	}
+	for _, link := range article.Links {
+		if link == to {
+			return true, true
+		}
+	}
+	// paths hold canonical titles, but articles may link to redirects
+	for _, link := range article.Links {
+		if links.Canonicalize(ctx, link) == to {
+			return true, true
+		}
+		if ctx.Err() != nil {
+			return false, false
+		}
+	}
+	return false, true
+}
+
+// Void takes the win away from the winner of a match if one of their flags is of one of kinds,
+// it reports whether the win was voided
+func Void(m *match.Match, kinds []string) bool {
+	if m.WinnerID == "" {
+		return false
+	}
+	voiding := make(map[string]bool, len(kinds))
+	for _, kind := range kinds {
+		voiding[kind] = true
+	}
+	for _, f := range m.FlagsOf(m.WinnerID) {
+		if !voiding[f.Kind] {
+			continue
+		}
+		for i := range m.Players {
+			if m.Players[i].ID == m.WinnerID {
+				m.Players[i].IsWinner = false
+				m.Players[i].Voided = true
+			}
+		}
+		m.WinnerID = ""
+		return true
+	}
+	return false
+}
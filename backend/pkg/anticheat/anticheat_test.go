@@ -0,0 +1,47 @@
+package anticheat
+
+import (
+	"context"
+	"testing"
+	"time"
+	"wikirace/pkg/match"
+)
+
+func TestCheckTimes(t *testing.T) {
+	start := time.Now()
+	at := func(offsets ...time.Duration) []time.Time {
+		times := make([]time.Time, 0, len(offsets))
+		for _, o := range offsets {
+			times = append(times, start.Add(o))
+		}
+		return times
+	}
+	tests := []struct {
+		name  string
+		times []time.Time
+		want  []string // flag kinds
+	}{
+		{"human pace", at(time.Second, 5*time.Second, 9*time.Second), []string{}},
+		{"recorded without move times", nil, []string{}},
+		{"fewer times than moves", at(time.Second), []string{}},
+		{"more times than moves", at(time.Second, 5*time.Second, 9*time.Second, 12*time.Second), []string{match.FlagOutOfOrder}},
+		{"too fast", at(time.Second, 5*time.Second, 5*time.Second+50*time.Millisecond), []string{match.FlagSpeed}},
+		{"decreasing", at(time.Second, 5*time.Second, 4*time.Second), []string{match.FlagOutOfOrder}},
+		{"before the round", at(-time.Second, 5*time.Second, 9*time.Second), []string{match.FlagOutOfOrder}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &match.Match{StartArticle: "Rome", TargetArticle: "Pizza", StartTime: start, EndTime: start.Add(time.Minute)}
+			m.Players = []match.Participant{{ID: "a", Paths: []string{"Rome", "Italy", "Pizza"}, PathTimes: tt.times}}
+			flags := Analyze(context.Background(), m, nil, Rules{MinMove: 300 * time.Millisecond})
+			if len(flags) != len(tt.want) {
+				t.Fatalf("got flags %+v, want kinds %v", flags, tt.want)
+			}
+			for i, f := range flags {
+				if f.Kind != tt.want[i] {
+					t.Fatalf("got flags %+v, want kinds %v", flags, tt.want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"wikirace/pkg/hint"
+	"wikirace/pkg/stderror"
+	"wikirace/pkg/wiki"
+)
+
+const (
+	StrategyRandom          = "random"           // follows random links it has not visited yet
+	StrategyGreedyLexical   = "greedy-lexical"   // follows the link whose title is most similar to the target
+	StrategyGreedyEmbedding = "greedy-embedding" // follows the link whose embedding is closest to the target's
+	StrategyOptimal         = "optimal"          // searches a shortest path before the race and follows it
+)
+
+// Strategies lists the strategies bots can play with
+var Strategies = []string{StrategyRandom, StrategyGreedyLexical, StrategyGreedyEmbedding, StrategyOptimal}
+
+// Position describes where a bot is in the race
+type Position struct {
+	Current  string
+	Target   string
+	Language string
+	Links    []string        // links on the current article
+	Visited  map[string]bool // articles the bot already reached
+}
+
+// Strategy picks the next article of a bot
+type Strategy interface {
+	// Next returns one of pos.Links
+	Next(ctx context.Context, pos Position) (string, error)
+}
+
+// Articles returns the sanitized articles bots read their links from, *wiki.Service implements it
+type Articles interface {
+	Article(ctx context.Context, title string) (*wiki.Article, error)
+}
+
+// New creates a fresh strategy by name. Each bot needs its own strategy, as strategies may keep state.
+// The greedy strategies rank links with the hint providers of hints, the optimal strategy reads
+// at most searchLimit articles to find its path.
+func New(name string, articles Articles, hints *hint.Service, searchLimit int) (Strategy, error) {
+	switch name {
+	case StrategyRandom:
+		return &randomWalk{}, nil
+	case StrategyGreedyLexical:
+		return newGreedy(hints, hint.ProviderLexical)
+	case StrategyGreedyEmbedding:
+		return newGreedy(hints, hint.ProviderEmbedding)
+	case StrategyOptimal:
+		fallback, err := newGreedy(hints, hint.ProviderLexical)
+		if err != nil {
+			return nil, err
+		}
+		return &optimal{articles: articles, searchLimit: searchLimit, fallback: fallback}, nil
+	default:
+		return nil, stderror.New(stderror.ErrValidation, errors.New("unknown bot strategy: "+name))
+	}
+}
@@ -0,0 +1,190 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"sync"
+	"time"
+	"wikirace/pkg/game"
+	"wikirace/pkg/logger"
+)
+
+// Games gives bots access to the games they play in
+type Games interface {
+	// Get returns the current state of a game
+	Get(gameCode string) (*game.Game, error)
+	// Move submits a move through the same code path as the moves of human players
+	Move(gameCode, playerID, article string) (*game.Game, error)
+}
+
+// Runner plays the rounds of bots in the background
+type Runner struct {
+	mu       sync.Mutex
+	running  map[string]context.CancelFunc // by gameCode/playerID
+	delay    time.Duration                 // default average time a bot takes per move
+	maxMoves int                           // a bot gives up after this many moves
+}
+
+// NewRunner creates a Runner whose bots take delay per move on average by default, with a random jitter of ±50%
+func NewRunner(delay time.Duration, maxMoves int) *Runner {
+	return &Runner{
+		running:  make(map[string]context.CancelFunc),
+		delay:    delay,
+		maxMoves: maxMoves,
+	}
+}
+
+// Start makes a bot play the current round of a game, replacing a round it is still playing.
+// The bot takes delay per move on average, zero uses the default delay of the runner.
+func (r *Runner) Start(g *game.Game, playerID string, strategy Strategy, delay time.Duration, articles Articles, games Games) {
+	if delay <= 0 {
+		delay = r.delay
+	}
+	key := g.Code + "/" + playerID
+	ctx, cancel := context.WithCancel(context.Background())
+	r.mu.Lock()
+	if stop, ok := r.running[key]; ok {
+		stop()
+	}
+	r.running[key] = cancel
+	r.mu.Unlock()
+
+	b := &player{
+		gameCode:  g.Code,
+		playerID:  playerID,
+		language:  g.Language,
+		start:     g.StartArticle,
+		target:    g.TargetArticle,
+		startTime: g.StartTime,
+		delay:     delay,
+		strategy:  strategy,
+		articles:  articles,
+		games:     games,
+	}
+	go func() {
+		defer func() {
+			r.mu.Lock()
+			// a newer round may have replaced the bot already
+			if _, ok := r.running[key]; ok && ctx.Err() == nil {
+				delete(r.running, key)
+			}
+			r.mu.Unlock()
+			cancel()
+		}()
+		b.play(ctx, r)
+	}()
+}
+
+// Stop ends the round of a bot
+func (r *Runner) Stop(gameCode, playerID string) {
+	key := gameCode + "/" + playerID
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if stop, ok := r.running[key]; ok {
+		stop()
+		delete(r.running, key)
+	}
+}
+
+// Running returns the number of bots currently playing
+func (r *Runner) Running() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.running)
+}
+
+// player is a bot playing one round
+type player struct {
+	gameCode  string
+	playerID  string
+	language  string
+	start     string
+	target    string
+	startTime time.Time
+	delay     time.Duration
+	strategy  Strategy
+	articles  Articles
+	games     Games
+}
+
+// play opens the start article like the frontend does, then follows links until the round is over
+func (b *player) play(ctx context.Context, r *Runner) {
+	trail := []string{}
+	visited := make(map[string]bool)
+	next := b.start
+	for moves := 0; moves < r.maxMoves; moves++ {
+		if !b.wait(ctx) || !b.playing() {
+			return
+		}
+		g, err := b.games.Move(b.gameCode, b.playerID, next)
+		if err != nil {
+			logger.Warnf("bot %s stopped in game %s: %v", b.playerID, b.gameCode, err)
+			return
+		}
+		if g.State != "playing" {
+			return
+		}
+		trail = append(trail, next)
+		visited[next] = true
+
+		if next, err = b.next(ctx, trail, visited); err != nil {
+			logger.Warnf("bot %s stopped in game %s: %v", b.playerID, b.gameCode, err)
+			return
+		}
+	}
+	logger.Debugf("bot %s gave up in game %s after %d moves", b.playerID, b.gameCode, r.maxMoves)
+}
+
+// playing reports whether the round the bot was started for is still in progress
+func (b *player) playing() bool {
+	g, err := b.games.Get(b.gameCode)
+	if err != nil {
+		return false
+	}
+	return g.State == "playing" && g.StartTime.Equal(b.startTime)
+}
+
+// wait sleeps for the delay of a move with a random jitter, it returns false if the bot was stopped meanwhile
+func (b *player) wait(ctx context.Context) bool {
+	delay := b.delay
+	if delay > 0 {
+		delay = delay/2 + time.Duration(rand.Int63n(int64(delay)))
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+// next picks the article after the last one of the trail
+func (b *player) next(ctx context.Context, trail []string, visited map[string]bool) (string, error) {
+	current := trail[len(trail)-1]
+	article, err := b.articles.Article(ctx, current)
+	if err != nil {
+		return "", err
+	}
+	for _, link := range article.Links {
+		if link == b.target {
+			return link, nil
+		}
+	}
+	if len(article.Links) == 0 {
+		// dead end, go back like a player pressing the back button
+		if len(trail) < 2 {
+			return "", errors.New("start article links to no other article: " + current)
+		}
+		return trail[len(trail)-2], nil
+	}
+	return b.strategy.Next(ctx, Position{
+		Current:  current,
+		Target:   b.target,
+		Language: b.language,
+		Links:    article.Links,
+		Visited:  visited,
+	})
+}
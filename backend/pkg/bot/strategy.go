@@ -0,0 +1,120 @@
+package bot
+
+import (
+	"context"
+	"math/rand"
+	"wikirace/pkg/hint"
+	"wikirace/pkg/logger"
+)
+
+// randomWalk follows random links, preferring articles it has not visited yet
+type randomWalk struct{}
+
+func (s *randomWalk) Next(_ context.Context, pos Position) (string, error) {
+	return randomLink(pos), nil
+}
+
+// randomLink returns a random unvisited link, or any link if all of them were visited
+func randomLink(pos Position) string {
+	unvisited := make([]string, 0, len(pos.Links))
+	for _, link := range pos.Links {
+		if !pos.Visited[link] {
+			unvisited = append(unvisited, link)
+		}
+	}
+	if len(unvisited) == 0 {
+		unvisited = pos.Links
+	}
+	return unvisited[rand.Intn(len(unvisited))]
+}
+
+// greedy follows the best unvisited suggestion of a hint provider
+type greedy struct {
+	hints    *hint.Service
+	provider string
+}
+
+func newGreedy(hints *hint.Service, provider string) (*greedy, error) {
+	// fail when the bot is added rather than in the middle of the race
+	if _, err := hints.Provider(provider); err != nil {
+		return nil, err
+	}
+	return &greedy{hints: hints, provider: provider}, nil
+}
+
+func (s *greedy) Next(ctx context.Context, pos Position) (string, error) {
+	suggestions, err := s.hints.Suggest(ctx, s.provider, hint.Request{
+		CurrentArticle: pos.Current,
+		TargetArticle:  pos.Target,
+		Language:       pos.Language,
+		Links:          pos.Links,
+		Limit:          hint.MaxLimit,
+	})
+	if err != nil {
+		return "", err
+	}
+	for _, suggestion := range suggestions {
+		if !pos.Visited[suggestion.Link] {
+			return suggestion.Link, nil
+		}
+	}
+	// no similar link left, wander off and try again from another article
+	return randomLink(pos), nil
+}
+
+// optimal searches a shortest path from the first article it is asked about and follows it,
+// bots playing it are only beatable because of their delays
+type optimal struct {
+	articles    Articles
+	searchLimit int
+	fallback    Strategy
+	path        map[string]string // next article on the shortest path, by article
+	searched    bool
+}
+
+func (s *optimal) Next(ctx context.Context, pos Position) (string, error) {
+	if !s.searched {
+		s.searched = true
+		s.path = s.search(ctx, pos.Current, pos.Target)
+	}
+	if next, ok := s.path[pos.Current]; ok {
+		return next, nil
+	}
+	return s.fallback.Next(ctx, pos)
+}
+
+// search runs a breadth-first search over the links of at most searchLimit articles and returns the
+// next article on the path for every article of the shortest path, or nil if no path was found
+func (s *optimal) search(ctx context.Context, start, target string) map[string]string {
+	parents := map[string]string{start: ""}
+	queue := []string{start}
+	for read := 0; len(queue) > 0 && read < s.searchLimit; read++ {
+		current := queue[0]
+		queue = queue[1:]
+		article, err := s.articles.Article(ctx, current)
+		if err != nil {
+			logger.Debugf("bot search skipped article %q: %v", current, err)
+			continue
+		}
+		for _, link := range article.Links {
+			if _, seen := parents[link]; seen {
+				continue
+			}
+			parents[link] = current
+			if link == target {
+				return walkBack(parents, link)
+			}
+			queue = append(queue, link)
+		}
+	}
+	return nil
+}
+
+// walkBack turns the parents found by search into the next article of every article on the path
+func walkBack(parents map[string]string, target string) map[string]string {
+	path := make(map[string]string)
+	for article := target; parents[article] != ""; article = parents[article] {
+		path[parents[article]] = article
+	}
+	return path
+}
@@ -7,9 +7,11 @@ import (
 
 type Config struct {
 	Server struct {
-		Env  string `yaml:"env"` // "dev", "staging", "prod"
-		Port string `yaml:"port"`
+		Env            string   `yaml:"env"` // "dev", "staging", "prod"
+		Port           string   `yaml:"port"`
+		TrustedProxies []string `yaml:"trustedProxies"` // proxies whose X-Forwarded-For header is trusted, empty trusts none
 	} `yaml:"server"`
+	CORS    CORSPolicy `yaml:"cors"`
 	MongoDB struct {
 		URI        string `yaml:"uri"`
 		DB         string `yaml:"db"`
@@ -18,8 +20,109 @@ type Config struct {
 	Logger struct {
 		Level string `yaml:"level"` // "debug", "info", "warn", "error", "dpanic", "panic", and "fatal"
 	} `yaml:"logger"`
+	Game struct {
+		MaxPlayers  int    `yaml:"maxPlayers"`  // upper bound for the player limit of a game, zero means no limit
+		MidGameJoin string `yaml:"midGameJoin"` // default policy for joining a playing game: "reject", "spectate" or "late"
+	} `yaml:"game"`
+	Daily struct {
+		Seed string        `yaml:"seed"` // changing the seed reshuffles every daily challenge
+		Pool []ArticlePair `yaml:"pool"` // candidate challenges, a built-in pool is used if empty
+	} `yaml:"daily"`
+	Matchmaking struct {
+		GroupSize      int `yaml:"groupSize"`      // a game is created as soon as this many players are queued
+		MaxWaitSeconds int `yaml:"maxWaitSeconds"` // or once the longest waiting player waited this long
+		// TicketTTLSeconds drops queued players that did not poll their status for this long
+		TicketTTLSeconds int `yaml:"ticketTTLSeconds"`
+	} `yaml:"matchmaking"`
+	Hints struct {
+		Provider       string `yaml:"provider"`       // provider used when a request does not name one: "lexical", "embedding" or "llm"
+		Budget         int    `yaml:"budget"`         // default hints per player and round, zero means no limit, negative disables hints
+		PenaltySeconds int    `yaml:"penaltySeconds"` // default time added to a player's time for every hint used
+		// AllowWithoutGame answers hint requests without a gameCode, which are not charged to any budget
+		AllowWithoutGame bool `yaml:"allowWithoutGame"`
+	} `yaml:"hints"`
+	Bots struct {
+		MaxPerGame  int `yaml:"maxPerGame"`  // bots a game may have, zero means no limit
+		DelayMs     int `yaml:"delayMs"`     // default average time a bot takes per move
+		MinDelayMs  int `yaml:"minDelayMs"`  // shortest average time per move a client may ask for
+		MaxMoves    int `yaml:"maxMoves"`    // a bot gives up after this many moves
+		SearchLimit int `yaml:"searchLimit"` // articles the optimal strategy may read to find its path
+	} `yaml:"bots"`
+	AntiCheat struct {
+		Enabled    bool     `yaml:"enabled"`    // analyze the paths of every finished round
+		MinMoveMs  int      `yaml:"minMoveMs"`  // moves faster than this are flagged, zero disables the speed check
+		CheckLinks bool     `yaml:"checkLinks"` // flag moves to articles the previous article does not link to
+		VoidOn     []string `yaml:"voidOn"`     // flag kinds that void a win, e.g. "target_jump", empty only flags
+	} `yaml:"antiCheat"`
+	RateLimit struct {
+		Enabled bool                  `yaml:"enabled"`
+		Backend string                `yaml:"backend"` // "memory" (default) or "mongo" to share the buckets between servers
+		Default RouteLimit            `yaml:"default"` // limits of the routes not listed in routes
+		Routes  map[string]RouteLimit `yaml:"routes"`  // limits by route, e.g. "/api/v1/games/addpath"
+	} `yaml:"rateLimit"`
+	Embeddings struct {
+		Provider   string `yaml:"provider"`   // "openai" or "fake", empty uses "openai" if an API key is configured
+		Dimensions int    `yaml:"dimensions"` // vector size of the fake provider
+		Cache      string `yaml:"cache"`      // where vectors are cached: "mongo" (default) or "memory"
+	} `yaml:"embeddings"`
+	Wiki struct {
+		Origin          string   `yaml:"origin"`          // "wikipedia" (default) or "fixtures"
+		Languages       []string `yaml:"languages"`       // languages games can be played in, e.g. "en" for en.wikipedia.org, defaults to ["en"]
+		DefaultLanguage string   `yaml:"defaultLanguage"` // language of games that do not choose one, defaults to the first language
+		FixturesDir     string   `yaml:"fixturesDir"`     // the fixtures origin serves the HTML files in <fixturesDir>/<language>
+		CacheSize       int      `yaml:"cacheSize"`       // articles kept in memory, zero disables the memory cache
+		CacheTTLHours   int      `yaml:"cacheTTLHours"`   // cached articles are fetched again after this long, zero means never
+		PersistentCache bool     `yaml:"persistentCache"` // also cache articles in the articles collection
+		Sanitize        struct {
+			RemoveClasses  []string `yaml:"removeClasses"`  // elements with these classes are removed, e.g. "navbox"
+			RemoveSections []string `yaml:"removeSections"` // sections with these titles are removed, e.g. "See also"
+			Namespaces     []string `yaml:"namespaces"`     // links into these namespaces are unlinked, e.g. "Category"
+			UnlinkSuffixes []string `yaml:"unlinkSuffixes"` // links to titles with these suffixes are unlinked
+			// localized section titles and namespace names added to the lists above for one language,
+			// e.g. de: [Weblinks] and de: [Kategorie, Datei]
+			LocalSections   map[string][]string `yaml:"localSections"`
+			LocalNamespaces map[string][]string `yaml:"localNamespaces"`
+		} `yaml:"sanitize"` // an empty list uses the built-in defaults
+	} `yaml:"wiki"`
+	OpenAI struct {
+		APIKey         string `yaml:"apiKey"`         // the embedding and llm hint providers are disabled without a key, see OpenAIKeyEnv
+		BaseURL        string `yaml:"baseURL"`        // defaults to the public OpenAI API
+		EmbeddingModel string `yaml:"embeddingModel"` // e.g. "text-embedding-ada-002"
+		ChatModel      string `yaml:"chatModel"`      // e.g. "gpt-4o-mini"
+	} `yaml:"openai"`
 }
 
+// CORSPolicy decides which web pages may call the API from the browser
+type CORSPolicy struct {
+	AllowedOrigins   []string `yaml:"allowedOrigins"`   // e.g. "https://wikirace.example", "https://*.example.com" for its subdomains or "*"
+	AllowedMethods   []string `yaml:"allowedMethods"`   // defaults to GET, POST, PUT, DELETE and OPTIONS
+	AllowedHeaders   []string `yaml:"allowedHeaders"`   // defaults to Content-Type, Authorization and Content-Length, "*" allows any
+	MaxAgeSeconds    int      `yaml:"maxAgeSeconds"`    // how long browsers may cache a preflight response, zero leaves it to the browser
+	AllowCredentials bool     `yaml:"allowCredentials"` // let pages send cookies, a "*" origin is then answered with the page's origin
+}
+
+// RouteLimit limits the requests to a route by client IP and by player
+type RouteLimit struct {
+	PerIP     Bucket `yaml:"perIP"`
+	PerPlayer Bucket `yaml:"perPlayer"` // players are identified by the playerID of the request
+}
+
+// Bucket is a token bucket refilled with Rate tokens per second up to Burst tokens, every request takes one token.
+// A zero Rate disables the limit, Burst is at least 1.
+type Bucket struct {
+	Rate  float64 `yaml:"rate"`
+	Burst int     `yaml:"burst"`
+}
+
+type ArticlePair struct {
+	Start  string `yaml:"start"`
+	Target string `yaml:"target"`
+}
+
+// OpenAIKeyEnv is the environment variable the OpenAI API key is read from when the config file has none,
+// so the key does not have to be stored in the config file
+const OpenAIKeyEnv = "OPENAI_API_KEY"
+
 func ParseConfig(configPath string) (Config, error) {
 	// Read the config file
 	file, err := os.Open(configPath)
@@ -32,5 +135,8 @@ func ParseConfig(configPath string) (Config, error) {
 	if err != nil {
 		return Config{}, err
 	}
+	if cfg.OpenAI.APIKey == "" {
+		cfg.OpenAI.APIKey = os.Getenv(OpenAIKeyEnv)
+	}
 	return cfg, nil
 }
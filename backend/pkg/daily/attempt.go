@@ -0,0 +1,140 @@
+package daily
+
+import (
+	"errors"
+	"time"
+	"wikirace/pkg/stderror"
+	"wikirace/pkg/wiki"
+)
+
+// Attempt is a player's solo run of a daily challenge, every player gets one attempt per day
+type Attempt struct {
+	Date          string        `json:"date"`
+	PlayerID      string        `json:"playerID,omitempty"`  // identity of the player, see game.Player.Identity, left out on leaderboards
+	AccountID     string        `json:"accountID,omitempty"` // account of the player, empty for anonymous players
+	Name          string        `json:"name"`
+	StartArticle  string        `json:"startArticle"`
+	TargetArticle string        `json:"targetArticle"`
+	StartTime     time.Time     `json:"startTime"`
+	EndTime       time.Time     `json:"endTime"`
+	Paths         []string      `json:"paths"` // list of article names
+	PathTimes     []time.Time   `json:"pathTimes"`
+	Finished      bool          `json:"finished"`
+	Clicks        int           `json:"clicks"`
+	Duration      time.Duration `json:"duration"` // time taken to reach the target, zero until finished
+}
+
+// Start returns the player's attempt of today's challenge, creating it if needed
+func Start(store Store, c Challenge, playerID, accountID, name string) (*Attempt, error) {
+	a, err := store.GetAttempt(c.Date, playerID)
+	if err == nil {
+		return a, nil
+	}
+	var stdErr *stderror.WrappedError
+	if !errors.As(err, &stdErr) || stdErr.Code != stderror.ErrAttemptNotFound.Code {
+		return nil, err
+	}
+	a = &Attempt{
+		Date:          c.Date,
+		PlayerID:      playerID,
+		AccountID:     accountID,
+		Name:          name,
+		StartArticle:  c.StartArticle,
+		TargetArticle: c.TargetArticle,
+		StartTime:     time.Now(),
+		Paths:         []string{},
+		PathTimes:     []time.Time{},
+	}
+	if err = store.SaveAttempt(a); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// AddPath records an article the player reached in their attempt of date and finishes it when the target is reached.
+// An empty date selects today's attempt, or yesterday's if the player started it before midnight and
+// has not started today's yet, so attempts can be finished after the day changed.
+func AddPath(store Store, date, playerID, path string, now time.Time) (*Attempt, error) {
+	a, err := currentAttempt(store, date, playerID, now)
+	if err != nil {
+		return nil, err
+	}
+	// a finished attempt cannot be changed
+	if a.Finished {
+		return a, nil
+	}
+	a.Paths = append(a.Paths, path)
+	a.PathTimes = append(a.PathTimes, now)
+	a.Clicks = len(a.Paths)
+	if wiki.SameTitle(path, a.TargetArticle) {
+		a.Finished = true
+		a.EndTime = now
+		a.Duration = now.Sub(a.StartTime)
+	}
+	if err = store.SaveAttempt(a); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// currentAttempt returns the attempt AddPath records articles in
+func currentAttempt(store Store, date, playerID string, now time.Time) (*Attempt, error) {
+	if date != "" {
+		return store.GetAttempt(date, playerID)
+	}
+	a, err := store.GetAttempt(Date(now), playerID)
+	var stdErr *stderror.WrappedError
+	if err == nil || !errors.As(err, &stdErr) || stdErr.Code != stderror.ErrAttemptNotFound.Code {
+		return a, err
+	}
+	yesterday, yErr := store.GetAttempt(Date(now.AddDate(0, 0, -1)), playerID)
+	if yErr != nil || yesterday.Finished {
+		return nil, err
+	}
+	return yesterday, nil
+}
+
+// Streak is the number of consecutive days a player finished the daily challenge
+type Streak struct {
+	Current  int    `json:"current"` // ends today, or yesterday if today's challenge is not finished yet
+	Longest  int    `json:"longest"`
+	LastDate string `json:"lastDate"` // last day the challenge was finished
+}
+
+// ComputeStreak derives a streak from the dates a player finished the challenge on
+func ComputeStreak(dates []string, now time.Time) Streak {
+	days := make(map[string]bool, len(dates))
+	var last string
+	for _, d := range dates {
+		days[d] = true
+		if d > last {
+			last = d
+		}
+	}
+	streak := Streak{LastDate: last}
+
+	// current streak
+	day := now.UTC()
+	if !days[Date(day)] {
+		day = day.AddDate(0, 0, -1)
+	}
+	for days[Date(day)] {
+		streak.Current++
+		day = day.AddDate(0, 0, -1)
+	}
+
+	// longest streak, counted from the first day of every run
+	for d := range days {
+		t, err := time.Parse(dateLayout, d)
+		if err != nil || days[Date(t.AddDate(0, 0, -1))] {
+			continue
+		}
+		length := 0
+		for days[Date(t)] {
+			length++
+			t = t.AddDate(0, 0, 1)
+		}
+		streak.Longest = max(streak.Longest, length)
+	}
+	return streak
+}
@@ -0,0 +1,56 @@
+package daily
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAddPathAfterMidnight(t *testing.T) {
+	store := NewMemoryStore()
+	challenge := Challenge{Date: "2026-10-18", StartArticle: "Rome", TargetArticle: "Pizza"}
+	if _, err := Start(store, challenge, "p1", "", "Ada"); err != nil {
+		t.Fatal(err)
+	}
+	afterMidnight := time.Date(2026, 10, 19, 0, 5, 0, 0, time.UTC)
+
+	a, err := AddPath(store, "", "p1", "Italy", afterMidnight)
+	if err != nil {
+		t.Fatalf("current attempt: %v", err)
+	}
+	if a.Date != "2026-10-18" || a.Clicks != 1 {
+		t.Fatalf("got attempt %+v", a)
+	}
+	a, err = AddPath(store, "2026-10-18", "p1", "Pizza", afterMidnight)
+	if err != nil {
+		t.Fatalf("attempt by date: %v", err)
+	}
+	if !a.Finished || a.Clicks != 2 {
+		t.Fatalf("got attempt %+v", a)
+	}
+
+	// a finished attempt of yesterday is not the current one
+	if _, err = AddPath(store, "", "p1", "Italy", afterMidnight); err == nil {
+		t.Fatal("added a path to a finished attempt of yesterday")
+	}
+	// older attempts are only found by date
+	if _, err = AddPath(store, "", "p1", "Italy", afterMidnight.AddDate(0, 0, 1)); err == nil {
+		t.Fatal("found an attempt of two days ago")
+	}
+}
+
+func TestAddPathPrefersToday(t *testing.T) {
+	store := NewMemoryStore()
+	if _, err := Start(store, Challenge{Date: "2026-10-18", TargetArticle: "Pizza"}, "p1", "", "Ada"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Start(store, Challenge{Date: "2026-10-19", TargetArticle: "Pasta"}, "p1", "", "Ada"); err != nil {
+		t.Fatal(err)
+	}
+	a, err := AddPath(store, "", "p1", "Italy", time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a.Date != "2026-10-19" {
+		t.Fatalf("got attempt of %v, want today's", a.Date)
+	}
+}
@@ -0,0 +1,91 @@
+package daily
+
+import (
+	"errors"
+	"hash/fnv"
+	"math/rand"
+	"time"
+	"wikirace/pkg/cfg"
+	"wikirace/pkg/stderror"
+)
+
+// dateLayout is the format of the date a challenge is played on, days are in UTC
+const dateLayout = "2006-01-02"
+
+// defaultPool is used when no pool is configured
+var defaultPool = []cfg.ArticlePair{
+	{Start: "Pizza", Target: "Moon"},
+	{Start: "Albert Einstein", Target: "Basketball"},
+	{Start: "Coffee", Target: "Roman Empire"},
+	{Start: "Penguin", Target: "Jazz"},
+	{Start: "Mount Everest", Target: "Chess"},
+	{Start: "Volcano", Target: "William Shakespeare"},
+	{Start: "Bicycle", Target: "Ancient Egypt"},
+	{Start: "Chocolate", Target: "Solar System"},
+}
+
+// Challenge is the start and target article everybody plays on a given day
+type Challenge struct {
+	Date          string `json:"date"`
+	StartArticle  string `json:"startArticle"`
+	TargetArticle string `json:"targetArticle"`
+}
+
+// Picker deterministically picks the challenge of a day from a pool
+type Picker struct {
+	seed string
+	pool []cfg.ArticlePair
+}
+
+// NewPicker creates a Picker, the same seed and pool always produce the same challenges
+func NewPicker(seed string, pool []cfg.ArticlePair) *Picker {
+	if len(pool) == 0 {
+		pool = defaultPool
+	}
+	return &Picker{
+		seed: seed,
+		pool: pool,
+	}
+}
+
+// For returns the challenge of the day containing t
+func (p *Picker) For(t time.Time) Challenge {
+	date := Date(t)
+	h := fnv.New64a()
+	h.Write([]byte(p.seed + "/" + date))
+	pair := p.pool[h.Sum64()%uint64(len(p.pool))]
+	return Challenge{
+		Date:          date,
+		StartArticle:  pair.Start,
+		TargetArticle: pair.Target,
+	}
+}
+
+// Random returns a random pair from the pool, used to pick articles for practice rounds
+func (p *Picker) Random() Challenge {
+	pair := p.pool[rand.Intn(len(p.pool))]
+	return Challenge{
+		StartArticle:  pair.Start,
+		TargetArticle: pair.Target,
+	}
+}
+
+// Date returns the challenge date of t
+func Date(t time.Time) string {
+	return t.UTC().Format(dateLayout)
+}
+
+// ParseDate validates a challenge date, an empty date means today
+func ParseDate(date string, now time.Time) (time.Time, error) {
+	if date == "" {
+		return now, nil
+	}
+	t, err := time.Parse(dateLayout, date)
+	if err != nil {
+		return time.Time{}, stderror.New(stderror.ErrBadRequest, errors.New("invalid date: "+date))
+	}
+	if t.After(now) {
+		return time.Time{}, stderror.New(stderror.ErrBadRequest, errors.New("date is in the future: "+date))
+	}
+	return t, nil
+}
@@ -0,0 +1,86 @@
+package daily
+
+import (
+	"context"
+	"errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"wikirace/pkg/logger"
+	"wikirace/pkg/mongodb"
+	"wikirace/pkg/stderror"
+)
+
+// MongoStore is a Store backed by the daily_attempts collection
+type MongoStore struct {
+	collection *mongo.Collection
+}
+
+// NewMongoStore creates a MongoStore using the given client
+func NewMongoStore(db *mongo.Client) *MongoStore {
+	return &MongoStore{
+		collection: mongodb.GetCollection(db, "wikirace", "daily_attempts"),
+	}
+}
+
+func (s *MongoStore) GetAttempt(date, playerID string) (*Attempt, error) {
+	filter := bson.M{"date": date, "playerid": playerID}
+	a := Attempt{}
+	err := s.collection.FindOne(nil, filter).Decode(&a)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			logger.Debugf("daily attempt not found: %v, %v", date, playerID)
+			return nil, stderror.New(stderror.ErrAttemptNotFound, errors.New("daily attempt not found, date: "+date+", player: "+playerID))
+		}
+		return nil, err
+	}
+	return &a, nil
+}
+
+func (s *MongoStore) SaveAttempt(a *Attempt) error {
+	filter := bson.M{"date": a.Date, "playerid": a.PlayerID}
+	_, err := s.collection.ReplaceOne(nil, filter, a, options.Replace().SetUpsert(true))
+	return err
+}
+
+func (s *MongoStore) Leaderboard(date string, offset, limit int) ([]Attempt, error) {
+	filter := bson.M{"date": date, "finished": true}
+	opts := options.Find().SetSort(bson.D{
+		{Key: "duration", Value: 1},
+		{Key: "clicks", Value: 1},
+		{Key: "endtime", Value: 1},
+	})
+	if offset > 0 {
+		opts.SetSkip(int64(offset))
+	}
+	if limit > 0 {
+		opts.SetLimit(int64(limit))
+	}
+	cursor, err := s.collection.Find(nil, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	attempts := make([]Attempt, 0)
+	if err = cursor.All(context.Background(), &attempts); err != nil {
+		return nil, err
+	}
+	return attempts, nil
+}
+
+func (s *MongoStore) FinishedDates(playerID string) ([]string, error) {
+	filter := bson.M{"playerid": playerID, "finished": true}
+	opts := options.Find().SetProjection(bson.M{"date": 1})
+	cursor, err := s.collection.Find(nil, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	attempts := make([]Attempt, 0)
+	if err = cursor.All(context.Background(), &attempts); err != nil {
+		return nil, err
+	}
+	dates := make([]string, 0, len(attempts))
+	for _, a := range attempts {
+		dates = append(dates, a.Date)
+	}
+	return dates, nil
+}
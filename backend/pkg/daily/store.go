@@ -0,0 +1,108 @@
+package daily
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"time"
+	"wikirace/pkg/stderror"
+)
+
+// Store persists daily challenge attempts
+type Store interface {
+	// GetAttempt returns a player's attempt of a day
+	GetAttempt(date, playerID string) (*Attempt, error)
+	// SaveAttempt creates or replaces a player's attempt of a day
+	SaveAttempt(a *Attempt) error
+	// Leaderboard returns the finished attempts of a day, fastest first
+	Leaderboard(date string, offset, limit int) ([]Attempt, error)
+	// FinishedDates returns the dates a player finished the challenge on
+	FinishedDates(playerID string) ([]string, error)
+}
+
+// MemoryStore is an in-memory Store, used for tests and local development
+type MemoryStore struct {
+	mu       sync.RWMutex
+	attempts map[string]Attempt // keyed by date and player ID
+}
+
+// NewMemoryStore creates an empty MemoryStore
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		attempts: make(map[string]Attempt),
+	}
+}
+
+func attemptKey(date, playerID string) string {
+	return date + "/" + playerID
+}
+
+func (s *MemoryStore) GetAttempt(date, playerID string) (*Attempt, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	a, ok := s.attempts[attemptKey(date, playerID)]
+	if !ok {
+		return nil, stderror.New(stderror.ErrAttemptNotFound, errors.New("daily attempt not found, date: "+date+", player: "+playerID))
+	}
+	a.Paths = append([]string{}, a.Paths...)
+	a.PathTimes = append([]time.Time{}, a.PathTimes...)
+	return &a, nil
+}
+
+func (s *MemoryStore) SaveAttempt(a *Attempt) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	stored := *a
+	stored.Paths = append([]string{}, a.Paths...)
+	stored.PathTimes = append([]time.Time{}, a.PathTimes...)
+	s.attempts[attemptKey(a.Date, a.PlayerID)] = stored
+	return nil
+}
+
+func (s *MemoryStore) Leaderboard(date string, offset, limit int) ([]Attempt, error) {
+	s.mu.RLock()
+	attempts := make([]Attempt, 0)
+	for _, a := range s.attempts {
+		if a.Date == date && a.Finished {
+			attempts = append(attempts, a)
+		}
+	}
+	s.mu.RUnlock()
+	sort.Slice(attempts, func(i, j int) bool {
+		return fasterAttempt(attempts[i], attempts[j])
+	})
+	if offset < 0 {
+		offset = 0
+	}
+	if offset >= len(attempts) {
+		return []Attempt{}, nil
+	}
+	attempts = attempts[offset:]
+	if limit > 0 && limit < len(attempts) {
+		attempts = attempts[:limit]
+	}
+	return attempts, nil
+}
+
+func (s *MemoryStore) FinishedDates(playerID string) ([]string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	dates := make([]string, 0)
+	for _, a := range s.attempts {
+		if a.PlayerID == playerID && a.Finished {
+			dates = append(dates, a.Date)
+		}
+	}
+	return dates, nil
+}
+
+// fasterAttempt orders attempts by duration, then clicks, then who finished first
+func fasterAttempt(a, b Attempt) bool {
+	if a.Duration != b.Duration {
+		return a.Duration < b.Duration
+	}
+	if a.Clicks != b.Clicks {
+		return a.Clicks < b.Clicks
+	}
+	return a.EndTime.Before(b.EndTime)
+}
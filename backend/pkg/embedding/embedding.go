@@ -0,0 +1,121 @@
+package embedding
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Embedder turns texts into embedding vectors
+type Embedder interface {
+	// Embed returns one vector per text, in the order of the texts
+	Embed(ctx context.Context, texts []string) ([][]float64, error)
+}
+
+// Entry is the cached embedding of an article title
+type Entry struct {
+	Model     string    `json:"model"`
+	Title     string    `json:"title"`
+	Vector    []float64 `json:"vector"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// Cache is an Embedder that only embeds titles it has not seen before with the given model.
+// Every title it is asked for is stored, so callers must only pass titles of existing articles.
+// The vectors are also kept in an in-process index, so titles seen before are neither read from
+// the store again nor compared by recomputing their norms.
+type Cache struct {
+	embedder Embedder
+	model    string
+	store    Store
+	index    *Index
+}
+
+// NewCache creates a Cache storing the vectors of embedder, which embeds with model, in store
+func NewCache(embedder Embedder, model string, store Store) *Cache {
+	return &Cache{
+		embedder: embedder,
+		model:    model,
+		store:    store,
+		index:    NewIndex(),
+	}
+}
+
+// Model returns the model the cached vectors were embedded with
+func (c *Cache) Model() string {
+	return c.model
+}
+
+// Index returns the index of every vector the cache returned so far
+func (c *Cache) Index() *Index {
+	return c.index
+}
+
+// Embed returns the vectors of texts, vectors taken from the index are normalized
+func (c *Cache) Embed(ctx context.Context, texts []string) ([][]float64, error) {
+	cached := make(map[string][]float64, len(texts))
+	stored := make([]string, 0, len(texts))
+	for _, t := range texts {
+		if v, ok := c.index.Get(t); ok {
+			cached[t] = v
+		} else {
+			stored = append(stored, t)
+		}
+	}
+	if len(stored) > 0 {
+		found, err := c.store.Get(c.model, stored)
+		if err != nil {
+			return nil, err
+		}
+		for t, v := range found {
+			cached[t] = v
+			c.index.Add(t, v)
+		}
+	}
+
+	// embed the titles that are not cached yet, each only once
+	missing := make([]string, 0)
+	seen := make(map[string]bool)
+	for _, t := range texts {
+		if _, ok := cached[t]; !ok && !seen[t] {
+			seen[t] = true
+			missing = append(missing, t)
+		}
+	}
+	if len(missing) > 0 {
+		vectors, err := c.embedder.Embed(ctx, missing)
+		if err != nil {
+			return nil, err
+		}
+		if len(vectors) != len(missing) {
+			return nil, errors.New("embedder returned a wrong number of vectors")
+		}
+		entries := make([]Entry, 0, len(missing))
+		now := time.Now()
+		for i, t := range missing {
+			cached[t] = vectors[i]
+			entries = append(entries, Entry{Model: c.model, Title: t, Vector: vectors[i], CreatedAt: now})
+		}
+		if err := c.store.Put(entries); err != nil {
+			return nil, err
+		}
+		for _, e := range entries {
+			c.index.Add(e.Title, e.Vector)
+		}
+	}
+
+	result := make([][]float64, len(texts))
+	for i, t := range texts {
+		result[i] = append([]float64{}, cached[t]...)
+	}
+	return result, nil
+}
+
+// Similar returns the k titles most similar to query, most similar first, embedding the titles not indexed yet
+func (c *Cache) Similar(ctx context.Context, query string, titles []string, k int) ([]Neighbor, error) {
+	vectors, err := c.Embed(ctx, append([]string{query}, titles...))
+	if err != nil {
+		return nil, err
+	}
+	return c.index.NearestAmong(vectors[0], titles, k), nil
+}
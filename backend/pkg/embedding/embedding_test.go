@@ -0,0 +1,97 @@
+package embedding
+
+import (
+	"context"
+	"math"
+	"testing"
+)
+
+// countingEmbedder records the texts it was asked to embed
+type countingEmbedder struct {
+	embedder Embedder
+	embedded []string
+}
+
+func (c *countingEmbedder) Embed(ctx context.Context, texts []string) ([][]float64, error) {
+	c.embedded = append(c.embedded, texts...)
+	return c.embedder.Embed(ctx, texts)
+}
+
+func TestFakeEmbedder(t *testing.T) {
+	e := NewFakeEmbedder(0)
+	vectors, err := e.Embed(context.Background(), []string{"Barack Obama", "Barack Obama", "Obama family", "Photosynthesis", ""})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(vectors[0]) != DefaultFakeDimensions {
+		t.Fatalf("got %d dimensions, want %d", len(vectors[0]), DefaultFakeDimensions)
+	}
+	if norm := math.Sqrt(dot(vectors[0], vectors[0])); math.Abs(norm-1) > 1e-9 {
+		t.Fatalf("got norm %v, want 1", norm)
+	}
+	if dot(vectors[0], vectors[1]) < 1-1e-9 {
+		t.Fatal("the same title got different vectors")
+	}
+	if related, unrelated := dot(vectors[0], vectors[2]), dot(vectors[0], vectors[3]); related <= unrelated {
+		t.Fatalf("related titles are less similar (%v) than unrelated ones (%v)", related, unrelated)
+	}
+	if dot(vectors[4], vectors[4]) != 0 {
+		t.Fatal("an empty title got a non-zero vector")
+	}
+}
+
+func TestCacheEmbedsTitlesOnce(t *testing.T) {
+	ctx := context.Background()
+	fake := NewFakeEmbedder(16)
+	counting := &countingEmbedder{embedder: fake}
+	store := NewMemoryStore()
+	cache := NewCache(counting, fake.Model(), store)
+
+	vectors, err := cache.Embed(ctx, []string{"Rome", "Pizza", "Rome"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(vectors) != 3 || len(counting.embedded) != 2 {
+		t.Fatalf("got %d vectors and %d embedded titles, want 3 and 2", len(vectors), len(counting.embedded))
+	}
+	if _, err = cache.Embed(ctx, []string{"Pizza", "Italy"}); err != nil {
+		t.Fatal(err)
+	}
+	if len(counting.embedded) != 3 || counting.embedded[2] != "Italy" {
+		t.Fatalf("got embedded titles %v, want only Italy embedded again", counting.embedded)
+	}
+
+	want, _ := fake.Embed(ctx, []string{"Rome"})
+	if dot(vectors[0], want[0]) < 1-1e-9 || dot(vectors[2], want[0]) < 1-1e-9 {
+		t.Fatal("cached vector differs from the embedder's")
+	}
+
+	// vectors are cached per model
+	other := NewCache(counting, "other-model", store)
+	if _, err = other.Embed(ctx, []string{"Rome"}); err != nil {
+		t.Fatal(err)
+	}
+	if len(counting.embedded) != 4 {
+		t.Fatalf("got embedded titles %v, want Rome embedded for the other model", counting.embedded)
+	}
+}
+
+func TestMemoryStoreCopiesVectors(t *testing.T) {
+	store := NewMemoryStore()
+	vector := []float64{1, 0}
+	if err := store.Put([]Entry{{Model: "m", Title: "Rome", Vector: vector}}); err != nil {
+		t.Fatal(err)
+	}
+	vector[0] = 2
+	got, err := store.Get("m", []string{"Rome", "Pizza"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || got["Rome"][0] != 1 {
+		t.Fatalf("got %v, want only the stored vector of Rome", got)
+	}
+	got["Rome"][0] = 3
+	if again, _ := store.Get("m", []string{"Rome"}); again["Rome"][0] != 1 {
+		t.Fatal("modifying a returned vector changed the store")
+	}
+}
@@ -0,0 +1,68 @@
+package embedding
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"strings"
+	"unicode"
+)
+
+// DefaultFakeDimensions is the vector size of a FakeEmbedder created with zero dimensions
+const DefaultFakeDimensions = 256
+
+// FakeEmbedder embeds titles without network access by hashing their words and character
+// trigrams into a fixed number of dimensions. Titles sharing words or spelling get similar vectors,
+// and the same title always gets the same vector.
+type FakeEmbedder struct {
+	dimensions int
+}
+
+// NewFakeEmbedder creates a FakeEmbedder producing vectors of the given size
+func NewFakeEmbedder(dimensions int) *FakeEmbedder {
+	if dimensions <= 0 {
+		dimensions = DefaultFakeDimensions
+	}
+	return &FakeEmbedder{dimensions: dimensions}
+}
+
+// Model returns the name vectors of this embedder are cached under
+func (e *FakeEmbedder) Model() string {
+	return fmt.Sprintf("fake-%d", e.dimensions)
+}
+
+func (e *FakeEmbedder) Embed(_ context.Context, texts []string) ([][]float64, error) {
+	vectors := make([][]float64, len(texts))
+	for i, t := range texts {
+		vectors[i] = e.embed(t)
+	}
+	return vectors, nil
+}
+
+// embed hashes the features of a title into a vector
+func (e *FakeEmbedder) embed(text string) []float64 {
+	vector := make([]float64, e.dimensions)
+	lower := strings.ToLower(text)
+	// whole words weigh more than spelling
+	for _, w := range strings.FieldsFunc(lower, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		e.add(vector, "w:"+w, 2)
+	}
+	runes := []rune(" " + lower + " ")
+	for i := 0; i+3 <= len(runes); i++ {
+		e.add(vector, "t:"+string(runes[i:i+3]), 1)
+	}
+	return normalize(vector)
+}
+
+// add adds a feature to a vector, the hash picks the dimension and the sign
+func (e *FakeEmbedder) add(vector []float64, feature string, weight float64) {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(feature))
+	sum := h.Sum64()
+	if sum>>63 == 1 {
+		weight = -weight
+	}
+	vector[sum%uint64(e.dimensions)] += weight
+}
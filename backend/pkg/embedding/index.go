@@ -0,0 +1,122 @@
+package embedding
+
+import (
+	"math"
+	"sort"
+	"sync"
+)
+
+// Neighbor is a title found by a nearest neighbour lookup
+type Neighbor struct {
+	Title      string  `json:"title"`
+	Similarity float64 `json:"similarity"` // cosine similarity to the query vector
+}
+
+// Index is an in-process vector index answering nearest neighbour queries by cosine similarity.
+// Vectors are normalized once when they are added, so a query only takes a dot product per title.
+// It scans the vectors it compares, which is fast enough for the number of articles seen by a server.
+type Index struct {
+	mu      sync.RWMutex
+	vectors map[string][]float64 // normalized vectors by title
+}
+
+// NewIndex creates an empty Index
+func NewIndex() *Index {
+	return &Index{
+		vectors: make(map[string][]float64),
+	}
+}
+
+// Add stores the vector of a title, replacing any previous one
+func (i *Index) Add(title string, vector []float64) {
+	normalized := normalize(vector)
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.vectors[title] = normalized
+}
+
+// Get returns the normalized vector of a title, if it is in the index
+func (i *Index) Get(title string) ([]float64, bool) {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	v, ok := i.vectors[title]
+	return v, ok
+}
+
+// Len returns the number of titles in the index
+func (i *Index) Len() int {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	return len(i.vectors)
+}
+
+// Nearest returns the k titles most similar to a vector, most similar first,
+// ties are broken by title so results are deterministic. A negative k returns every title.
+func (i *Index) Nearest(vector []float64, k int) []Neighbor {
+	query := normalize(vector)
+	i.mu.RLock()
+	neighbors := make([]Neighbor, 0, len(i.vectors))
+	for title, v := range i.vectors {
+		neighbors = append(neighbors, Neighbor{Title: title, Similarity: dot(query, v)})
+	}
+	i.mu.RUnlock()
+	return nearest(neighbors, k)
+}
+
+// NearestAmong is Nearest limited to the given titles, titles that are not in the index are left out
+func (i *Index) NearestAmong(vector []float64, titles []string, k int) []Neighbor {
+	query := normalize(vector)
+	seen := make(map[string]bool, len(titles))
+	i.mu.RLock()
+	neighbors := make([]Neighbor, 0, len(titles))
+	for _, title := range titles {
+		v, ok := i.vectors[title]
+		if !ok || seen[title] {
+			continue
+		}
+		seen[title] = true
+		neighbors = append(neighbors, Neighbor{Title: title, Similarity: dot(query, v)})
+	}
+	i.mu.RUnlock()
+	return nearest(neighbors, k)
+}
+
+// nearest sorts neighbors by similarity and keeps the first k
+func nearest(neighbors []Neighbor, k int) []Neighbor {
+	sort.Slice(neighbors, func(a, b int) bool {
+		if neighbors[a].Similarity != neighbors[b].Similarity {
+			return neighbors[a].Similarity > neighbors[b].Similarity
+		}
+		return neighbors[a].Title < neighbors[b].Title
+	})
+	if k >= 0 && k < len(neighbors) {
+		neighbors = neighbors[:k]
+	}
+	return neighbors
+}
+
+// normalize returns a copy of a vector scaled to unit length, a zero vector stays zero
+func normalize(vector []float64) []float64 {
+	var norm float64
+	for _, x := range vector {
+		norm += x * x
+	}
+	normalized := make([]float64, len(vector))
+	if norm == 0 {
+		return normalized
+	}
+	norm = math.Sqrt(norm)
+	for j, x := range vector {
+		normalized[j] = x / norm
+	}
+	return normalized
+}
+
+// dot returns the dot product of two vectors, extra dimensions of the longer one are ignored
+func dot(a, b []float64) float64 {
+	var sum float64
+	for j := 0; j < len(a) && j < len(b); j++ {
+		sum += a[j] * b[j]
+	}
+	return sum
+}
@@ -0,0 +1,95 @@
+package embedding
+
+import (
+	"context"
+	"fmt"
+	"testing"
+)
+
+func titles(neighbors []Neighbor) []string {
+	result := make([]string, 0, len(neighbors))
+	for _, n := range neighbors {
+		result = append(result, n.Title)
+	}
+	return result
+}
+
+func TestIndexNearest(t *testing.T) {
+	index := NewIndex()
+	index.Add("east", []float64{2, 0})
+	index.Add("north", []float64{0, 3})
+	index.Add("northeast", []float64{1, 1})
+	index.Add("also east", []float64{5, 0})
+	index.Add("zero", []float64{0, 0})
+
+	tests := []struct {
+		name   string
+		titles []string // nil searches the whole index
+		k      int
+		want   []string
+	}{
+		{"ties by title", nil, 3, []string{"also east", "east", "northeast"}},
+		{"every title", nil, -1, []string{"also east", "east", "northeast", "north", "zero"}},
+		{"among titles", []string{"north", "northeast", "north"}, 5, []string{"northeast", "north"}},
+		{"unknown titles are left out", []string{"south", "east"}, 5, []string{"east"}},
+		{"limit among titles", []string{"north", "northeast", "east"}, 1, []string{"east"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []Neighbor
+			if tt.titles == nil {
+				got = index.Nearest([]float64{3, 0}, tt.k)
+			} else {
+				got = index.NearestAmong([]float64{3, 0}, tt.titles, tt.k)
+			}
+			if names := titles(got); fmt.Sprint(names) != fmt.Sprint(tt.want) {
+				t.Fatalf("got %v, want %v", names, tt.want)
+			}
+		})
+	}
+	if got := index.Nearest([]float64{1, 0}, 1); got[0].Similarity < 1-1e-9 {
+		t.Fatalf("got similarity %v, want 1 for the same direction", got[0].Similarity)
+	}
+}
+
+func TestCacheSimilarUsesIndex(t *testing.T) {
+	ctx := context.Background()
+	fake := NewFakeEmbedder(0)
+	counting := &countingEmbedder{embedder: fake}
+	cache := NewCache(counting, fake.Model(), NewMemoryStore())
+
+	links := []string{"Photosynthesis", "Obama family", "Chicago"}
+	neighbors, err := cache.Similar(ctx, "Barack Obama", links, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(neighbors) != 2 || neighbors[0].Title != "Obama family" {
+		t.Fatalf("got %v, want Obama family first", titles(neighbors))
+	}
+	if cache.Index().Len() != 4 {
+		t.Fatalf("got %d indexed titles, want 4", cache.Index().Len())
+	}
+
+	// titles seen before are answered from the index without embedding them again
+	if _, err = cache.Similar(ctx, "Barack Obama", links, 2); err != nil {
+		t.Fatal(err)
+	}
+	if len(counting.embedded) != 4 {
+		t.Fatalf("got embedded titles %v, want every title embedded once", counting.embedded)
+	}
+
+	// a new cache over the same store fills its index from the store
+	store := NewMemoryStore()
+	first := NewCache(fake, fake.Model(), store)
+	if _, err = first.Embed(ctx, []string{"Rome"}); err != nil {
+		t.Fatal(err)
+	}
+	counting.embedded = nil
+	second := NewCache(counting, fake.Model(), store)
+	if _, err = second.Embed(ctx, []string{"Rome"}); err != nil {
+		t.Fatal(err)
+	}
+	if len(counting.embedded) != 0 || second.Index().Len() != 1 {
+		t.Fatalf("embedded %v and indexed %d titles, want Rome read from the store", counting.embedded, second.Index().Len())
+	}
+}
@@ -0,0 +1,54 @@
+package embedding
+
+import (
+	"context"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"wikirace/pkg/mongodb"
+)
+
+// MongoStore is a Store backed by the embeddings collection
+type MongoStore struct {
+	collection *mongo.Collection
+}
+
+// NewMongoStore creates a MongoStore using the given client
+func NewMongoStore(db *mongo.Client) *MongoStore {
+	return &MongoStore{
+		collection: mongodb.GetCollection(db, "wikirace", "embeddings"),
+	}
+}
+
+func (s *MongoStore) Get(model string, titles []string) (map[string][]float64, error) {
+	filter := bson.M{"model": model, "title": bson.M{"$in": titles}}
+	cursor, err := s.collection.Find(nil, filter)
+	if err != nil {
+		return nil, err
+	}
+	found := make([]Entry, 0, len(titles))
+	if err = cursor.All(context.Background(), &found); err != nil {
+		return nil, err
+	}
+	vectors := make(map[string][]float64, len(found))
+	for _, e := range found {
+		vectors[e.Title] = e.Vector
+	}
+	return vectors, nil
+}
+
+func (s *MongoStore) Put(entries []Entry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+	// a single round trip for all entries
+	models := make([]mongo.WriteModel, 0, len(entries))
+	for _, e := range entries {
+		models = append(models, mongo.NewReplaceOneModel().
+			SetFilter(bson.M{"model": e.Model, "title": e.Title}).
+			SetReplacement(e).
+			SetUpsert(true))
+	}
+	_, err := s.collection.BulkWrite(nil, models, options.BulkWrite().SetOrdered(false))
+	return err
+}
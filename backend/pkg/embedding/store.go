@@ -0,0 +1,53 @@
+package embedding
+
+import (
+	"sync"
+)
+
+// Store persists embedding vectors by model and title
+type Store interface {
+	// Get returns the cached vectors of the titles by title, titles that are not cached are left out
+	Get(model string, titles []string) (map[string][]float64, error)
+	// Put stores entries, replacing the vectors already stored for the same model and title
+	Put(entries []Entry) error
+}
+
+// MemoryStore is an in-memory Store, used for tests and local development
+type MemoryStore struct {
+	mu      sync.RWMutex
+	entries map[string]Entry
+}
+
+// NewMemoryStore creates an empty MemoryStore
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		entries: make(map[string]Entry),
+	}
+}
+
+func (s *MemoryStore) Get(model string, titles []string) (map[string][]float64, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	vectors := make(map[string][]float64, len(titles))
+	for _, t := range titles {
+		if e, ok := s.entries[key(model, t)]; ok {
+			vectors[t] = append([]float64{}, e.Vector...)
+		}
+	}
+	return vectors, nil
+}
+
+func (s *MemoryStore) Put(entries []Entry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, e := range entries {
+		e.Vector = append([]float64{}, e.Vector...)
+		s.entries[key(e.Model, e.Title)] = e
+	}
+	return nil
+}
+
+// key identifies the entry of a title embedded with a model
+func key(model, title string) string {
+	return model + "\x00" + title
+}
@@ -0,0 +1,91 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"go.mongodb.org/mongo-driver/mongo"
+	"time"
+	"wikirace/pkg/helper"
+	"wikirace/pkg/logger"
+	"wikirace/pkg/mongodb"
+	"wikirace/pkg/stderror"
+)
+
+// botIDPrefix marks the player IDs of bots, so they never collide with the IDs generated by the frontend
+const botIDPrefix = "bot-"
+
+// BotSettings describe how a bot player plays
+type BotSettings struct {
+	Strategy string        `json:"strategy"` // see bot.Strategies
+	Delay    time.Duration `json:"delay"`    // average time per move, zero uses the server default
+}
+
+// IsBot reports whether the player is a bot
+func (p Player) IsBot() bool {
+	return p.Bot != nil
+}
+
+// Bots returns the bots playing in the game
+func (g *Game) Bots() []Player {
+	bots := make([]Player, 0)
+	for _, p := range g.Players {
+		if p.IsBot() {
+			bots = append(bots, p)
+		}
+	}
+	return bots
+}
+
+// AddBot adds a bot playing with the given settings to a game on behalf of its leader, at most maxBots bots
+// may join a game, zero means no limit. Bots join lobbies, and solo games so the player has an opponent.
+func AddBot(gameCode, leaderID, name string, settings BotSettings, maxBots int, db *mongo.Client) (*Game, *Player, error) {
+	// get the game from the database
+	collection := mongodb.GetCollection(db, "wikirace", "games")
+	filter := map[string]string{
+		"code": gameCode,
+	}
+	game := Game{}
+	err := collection.FindOne(nil, filter).Decode(&game)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			logger.Debugf("game not found: %v", gameCode)
+			return nil, nil, stderror.New(stderror.ErrGameNotFound, errors.New("game not found, code: "+gameCode))
+		}
+		return nil, nil, err
+	}
+
+	if err = game.CheckLeader(leaderID); err != nil {
+		return nil, nil, err
+	}
+	if game.State != "waiting" && !(game.IsSolo() && game.State == "playing") {
+		return nil, nil, stderror.New(stderror.ErrGameInProgress, errors.New("cannot add bot to a started game, code: "+gameCode))
+	}
+	if game.MaxPlayers > 0 && len(game.Players) >= game.MaxPlayers {
+		return nil, nil, stderror.New(stderror.ErrGameFull, errors.New("game is full, code: "+gameCode))
+	}
+	if maxBots > 0 && len(game.Bots()) >= maxBots {
+		return nil, nil, stderror.New(stderror.ErrGameFull, fmt.Errorf("game already has %d bots, code: %s", maxBots, gameCode))
+	}
+
+	code, err := helper.GenerateRandomCode(8)
+	if err != nil {
+		return nil, nil, err
+	}
+	if name == "" {
+		name = "Bot"
+	}
+	bot := Player{
+		ID:   botIDPrefix + code,
+		Name: uniqueName(&game, name),
+		Bot:  &settings,
+	}
+	game.Players = append(game.Players, bot)
+	game.ExpiresAfter = time.Now().Add(expirationTime)
+
+	// update the game in the database
+	_, err = collection.ReplaceOne(nil, filter, game)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &game, &bot, nil
+}
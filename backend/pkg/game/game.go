@@ -1,44 +1,117 @@
 package game
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"strings"
 	"time"
 	"wikirace/pkg/helper"
 	"wikirace/pkg/logger"
 	"wikirace/pkg/mongodb"
 	"wikirace/pkg/stderror"
+	"wikirace/pkg/wiki"
 )
 
 const (
 	expirationTime = 4 * time.Hour
+	// times a move is tried again when the round changes while it is added, see AddPath
+	addPathAttempts = 3
 )
 
+// game types, games stored before types were introduced have an empty type and are multiplayer games
+const (
+	TypeMultiplayer = "multiplayer"
+	TypeSolo        = "solo" // single player practice round, started right away without a lobby
+)
+
+// policies for players joining a game that is already playing
+const (
+	MidGameJoinReject   = "reject"   // refuse the player
+	MidGameJoinSpectate = "spectate" // let the player watch the race
+	MidGameJoinLate     = "late"     // let the player race from the start article with a late start
+)
+
+// Settings are the options chosen by the leader when creating a game
+type Settings struct {
+	Public      bool   // list the lobby in the lobby browser
+	MaxPlayers  int    // zero means no limit
+	MidGameJoin string // one of the MidGameJoin policies, empty means MidGameJoinReject
+	Password    string // players have to enter it to join, empty means no password
+	LivePaths   bool   // show every player's path to everybody while the race is in progress
+	Language    string // language of the Wikipedia the game is played on, e.g. "en"
+	Hints       HintRules
+}
+
 type Game struct {
-	Code          string    `json:"code"`
-	Players       []Player  `json:"players"`
-	State         string    `json:"state"`
-	StartArticle  string    `json:"startArticle"`
-	TargetArticle string    `json:"targetArticle"`
-	StartTime     time.Time `json:"startTime"`
-	EndTime       time.Time `json:"endTime"`
-	ExpiresAfter  time.Time `json:"expiresAfter"`
+	Code                   string            `json:"code"`
+	Type                   string            `json:"type"`
+	Public                 bool              `json:"public"`      // public lobbies are listed in the lobby browser
+	MaxPlayers             int               `json:"maxPlayers"`  // zero means no limit
+	MidGameJoin            string            `json:"midGameJoin"` // what happens to players joining while the game is playing
+	Protected              bool              `json:"protected"`   // joining requires the lobby password
+	LivePaths              bool              `json:"livePaths"`   // opponents' paths are visible while playing
+	Language               string            `json:"language"`    // language of the Wikipedia the game is played on, empty for games created before languages
+	HintBudget             int               `json:"hintBudget"`  // hints each player may use per round, see HintRules
+	HintPenalty            time.Duration     `json:"hintPenalty"` // added to a player's time for every hint used
+	Players                []Player          `json:"players"`
+	Spectators             []Player          `json:"spectators"` // watch the game without racing
+	State                  string            `json:"state"`
+	StartArticle           string            `json:"startArticle"`
+	TargetArticle          string            `json:"targetArticle"`
+	StartTime              time.Time         `json:"startTime"`
+	EndTime                time.Time         `json:"endTime"`
+	ExpiresAfter           time.Time         `json:"expiresAfter"`
+	PasswordHash           string            `json:"-"` // salted hash of the lobby password
+	ClientPasswordFailures []PasswordFailure `json:"-"` // recent wrong passwords by client, used to limit guessing
 }
 
 type Player struct {
-	ID       string   `json:"id"`
-	Name     string   `json:"name"`
-	IsLeader bool     `json:"isLeader"`
-	IsWinner bool     `json:"isWinner"`
-	Paths    []string `json:"paths"` // list of article names
+	ID        string       `json:"id"`
+	AccountID string       `json:"accountID,omitempty"` // persistent account of the player, empty for anonymous players
+	Name      string       `json:"name"`
+	IsLeader  bool         `json:"isLeader"`
+	IsWinner  bool         `json:"isWinner"`
+	Voided    bool         `json:"voided,omitempty"`          // reached the target first, but the win was voided by the anti-cheat analysis
+	Bot       *BotSettings `json:"bot,omitempty"`             // set for bot players
+	Paths     []string     `json:"paths"`                     // list of article names
+	HintsUsed int          `json:"hintsUsed"`                 // hints used in the current round
+	PathTimes []time.Time  `json:"pathTimes"`                 // time each article in Paths was reached
+	Clicks    int          `json:"clicks" bson:"-"`           // number of articles visited, always visible
+	Rating    int          `json:"rating,omitempty" bson:"-"` // skill rating, filled in when the game is returned
+	Client    string       `json:"-"`                         // IP address the player joined from, empty for bots
+}
+
+// Identity returns the ID that stats, ratings and match history of the player are stored under:
+// the account ID if the player has an account, or the anonymous player ID otherwise
+func (p Player) Identity() string {
+	if p.AccountID != "" {
+		return p.AccountID
+	}
+	return p.ID
+}
+
+// titles returns the title rules of the Wikipedia the game is played on
+func (g *Game) titles() wiki.TitleRules {
+	return wiki.TitleRulesFor(g.Language)
 }
 
-// CreateGame stores a new game to the database
-func CreateGame(leaderName, playerID string, db *mongo.Client) (*Game, error) {
+// IsSolo reports whether the game is a single player practice round
+func (g *Game) IsSolo() bool {
+	return g.Type == TypeSolo
+}
+
+// CreateGame stores a new game to the database, client is the IP address of the leader
+func CreateGame(leaderName, playerID, accountID, client string, settings Settings, db *mongo.Client) (*Game, error) {
 	leader := Player{
-		ID:       playerID,
-		Name:     leaderName,
-		IsLeader: true,
+		ID:        playerID,
+		AccountID: accountID,
+		Name:      leaderName,
+		IsLeader:  true,
+		Client:    client,
 	}
 	code, err := helper.GenerateRandomCode(6)
 	// TODO: check if code already exists
@@ -46,7 +119,15 @@ func CreateGame(leaderName, playerID string, db *mongo.Client) (*Game, error) {
 		return nil, err
 	}
 	game := Game{
-		Code: code,
+		Code:        code,
+		Type:        TypeMultiplayer,
+		Public:      settings.Public,
+		MaxPlayers:  settings.MaxPlayers,
+		MidGameJoin: settings.MidGameJoin,
+		LivePaths:   settings.LivePaths,
+		Language:    settings.Language,
+		HintBudget:  settings.Hints.Budget,
+		HintPenalty: settings.Hints.Penalty,
 		Players: []Player{
 			leader,
 		},
@@ -55,6 +136,9 @@ func CreateGame(leaderName, playerID string, db *mongo.Client) (*Game, error) {
 		TargetArticle: "",
 		ExpiresAfter:  time.Now().Add(expirationTime),
 	}
+	if err = game.setPassword(settings.Password); err != nil {
+		return nil, err
+	}
 
 	// save the game to the database
 	collection := mongodb.GetCollection(db, "wikirace", "games")
@@ -65,8 +149,111 @@ func CreateGame(leaderName, playerID string, db *mongo.Client) (*Game, error) {
 	return &game, nil
 }
 
-// JoinGame adds a player to a game and updates the database
-func JoinGame(gameCode, playerID, playerName string, db *mongo.Client) (*Game, error) {
+// CreateSoloGame stores a new single player game that is already playing
+func CreateSoloGame(playerName, playerID, accountID, client, language, startArticle, targetArticle string, hints HintRules, db *mongo.Client) (*Game, error) {
+	player := Player{
+		ID:        playerID,
+		AccountID: accountID,
+		Name:      playerName,
+		IsLeader:  true,
+		Client:    client,
+	}
+	code, err := helper.GenerateRandomCode(6)
+	if err != nil {
+		return nil, err
+	}
+	game := Game{
+		Code:        code,
+		Type:        TypeSolo,
+		Language:    language,
+		HintBudget:  hints.Budget,
+		HintPenalty: hints.Penalty,
+		Players: []Player{
+			player,
+		},
+		State:        "playing",
+		StartTime:    time.Now(),
+		ExpiresAfter: time.Now().Add(expirationTime),
+	}
+	game.StartArticle = game.titles().Normalize(startArticle)
+	game.TargetArticle = game.titles().Normalize(targetArticle)
+
+	// save the game to the database
+	collection := mongodb.GetCollection(db, "wikirace", "games")
+	_, err = collection.InsertOne(nil, game)
+	if err != nil {
+		return nil, err
+	}
+	return &game, nil
+}
+
+// Lobby summarizes a public game that is waiting for players
+type Lobby struct {
+	Code          string    `json:"code"`
+	LeaderName    string    `json:"leaderName"`
+	PlayerCount   int       `json:"playerCount"`
+	MaxPlayers    int       `json:"maxPlayers"` // zero means no limit
+	MidGameJoin   string    `json:"midGameJoin"`
+	Protected     bool      `json:"protected"`
+	Language      string    `json:"language"`
+	StartArticle  string    `json:"startArticle"`
+	TargetArticle string    `json:"targetArticle"`
+	ExpiresAfter  time.Time `json:"expiresAfter"`
+}
+
+// ListLobbies returns the public games that are still waiting for players, most recently active first
+func ListLobbies(offset, limit int, db *mongo.Client) ([]Lobby, error) {
+	collection := mongodb.GetCollection(db, "wikirace", "games")
+	filter := bson.M{
+		"public":       true,
+		"state":        "waiting",
+		"type":         bson.M{"$ne": TypeSolo},
+		"expiresafter": bson.M{"$gt": time.Now()},
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "expiresafter", Value: -1}})
+	if offset > 0 {
+		opts.SetSkip(int64(offset))
+	}
+	if limit > 0 {
+		opts.SetLimit(int64(limit))
+	}
+	cursor, err := collection.Find(nil, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	games := make([]Game, 0)
+	if err = cursor.All(context.Background(), &games); err != nil {
+		return nil, err
+	}
+
+	lobbies := make([]Lobby, 0, len(games))
+	for _, game := range games {
+		lobby := Lobby{
+			Code:          game.Code,
+			PlayerCount:   len(game.Players),
+			MaxPlayers:    game.MaxPlayers,
+			MidGameJoin:   game.MidGameJoin,
+			Protected:     game.Protected,
+			Language:      game.Language,
+			StartArticle:  game.StartArticle,
+			TargetArticle: game.TargetArticle,
+			ExpiresAfter:  game.ExpiresAfter,
+		}
+		for _, p := range game.Players {
+			if p.IsLeader {
+				lobby.LeaderName = p.Name
+			}
+		}
+		lobbies = append(lobbies, lobby)
+	}
+	return lobbies, nil
+}
+
+// JoinGame adds a player to a game and updates the database. The password of a protected game is checked
+// for every join, wrong passwords count against client.
+// Joining again with the same player ID returns the game unchanged, and a display name that is
+// already taken gets a numeric suffix.
+func JoinGame(gameCode, playerID, accountID, playerName, password, client string, db *mongo.Client) (*Game, error) {
 	// get the game from the database
 	collection := mongodb.GetCollection(db, "wikirace", "games")
 	filter := map[string]string{
@@ -83,13 +270,56 @@ func JoinGame(gameCode, playerID, playerName string, db *mongo.Client) (*Game, e
 		return nil, err
 	}
 
-	// add the player to the game
+	// solo games are private to the player who created them
+	if game.IsSolo() {
+		return nil, stderror.New(stderror.ErrGameNotJoinable, errors.New("cannot join solo game, code: "+gameCode))
+	}
+
+	// player IDs are visible to other players, so rejoining requires the password as well
+	if err = game.checkPassword(password, client, time.Now()); err != nil {
+		return nil, savePasswordFailure(collection, filter, &game, err)
+	}
+
+	// a player or spectator that is already in the game rejoins
+	if game.IsMember(playerID) {
+		return &game, nil
+	}
+
 	player := Player{
-		ID:       playerID,
-		Name:     playerName,
-		IsLeader: false,
+		ID:        playerID,
+		AccountID: accountID,
+		Name:      uniqueName(&game, playerName),
+		IsLeader:  false,
+		Client:    client,
+	}
+
+	// apply the mid-game join policy
+	spectate := false
+	if game.State == "playing" {
+		switch game.MidGameJoin {
+		case MidGameJoinSpectate:
+			spectate = true
+		case MidGameJoinLate:
+		default:
+			return nil, stderror.New(stderror.ErrGameInProgress, errors.New("game already started, code: "+gameCode))
+		}
+	}
+
+	// add the player to the game
+	if spectate {
+		if hasAccount(game.Players, accountID) {
+			return nil, stderror.New(stderror.ErrPlayerSpectator, errors.New("account already plays, code: "+gameCode))
+		}
+		game.Spectators = append(game.Spectators, player)
+	} else {
+		if hasAccount(game.Spectators, accountID) {
+			return nil, stderror.New(stderror.ErrPlayerSpectator, errors.New("account already spectates, code: "+gameCode))
+		}
+		if game.MaxPlayers > 0 && len(game.Players) >= game.MaxPlayers {
+			return nil, stderror.New(stderror.ErrGameFull, errors.New("game is full, code: "+gameCode))
+		}
+		game.Players = append(game.Players, player)
 	}
-	game.Players = append(game.Players, player)
 	game.ExpiresAfter = time.Now().Add(expirationTime)
 
 	// update the game in the database
@@ -100,8 +330,12 @@ func JoinGame(gameCode, playerID, playerName string, db *mongo.Client) (*Game, e
 	return &game, nil
 }
 
-// GetGame returns a game from the database
-func GetGame(gameCode string, db *mongo.Client) (*Game, error) {
+// SpectateGame adds a spectator to a game and updates the database.
+// Spectators see the whole game but cannot play, and do not count towards the player limit.
+// An account playing the game cannot spectate it, as spectators see the paths of every player.
+// Clients are not compared, players behind the same NAT or proxy may watch each other.
+// Joining again with the ID of a player or spectator returns the game unchanged, the password is checked like in JoinGame.
+func SpectateGame(gameCode, playerID, accountID, playerName, password, client string, db *mongo.Client) (*Game, error) {
 	// get the game from the database
 	collection := mongodb.GetCollection(db, "wikirace", "games")
 	filter := map[string]string{
@@ -116,11 +350,175 @@ func GetGame(gameCode string, db *mongo.Client) (*Game, error) {
 		}
 		return nil, err
 	}
+
+	if err = game.checkPassword(password, client, time.Now()); err != nil {
+		return nil, savePasswordFailure(collection, filter, &game, err)
+	}
+
+	if game.IsMember(playerID) {
+		return &game, nil
+	}
+
+	if hasAccount(game.Players, accountID) {
+		return nil, stderror.New(stderror.ErrPlayerSpectator, errors.New("account already plays, code: "+gameCode))
+	}
+
+	// add the spectator to the game
+	spectator := Player{
+		ID:        playerID,
+		AccountID: accountID,
+		Name:      uniqueName(&game, playerName),
+		Client:    client,
+	}
+	game.Spectators = append(game.Spectators, spectator)
+	game.ExpiresAfter = time.Now().Add(expirationTime)
+
+	// update the game in the database
+	_, err = collection.ReplaceOne(nil, filter, game)
+	if err != nil {
+		return nil, err
+	}
 	return &game, nil
 }
 
-// StartGame starts a game
-func StartGame(gameCode string, startArticle string, targetArticle string, db *mongo.Client) (*Game, error) {
+// hasAccount reports whether one of players joined with the account, an empty account never matches
+func hasAccount(players []Player, accountID string) bool {
+	for _, p := range players {
+		if accountID != "" && p.AccountID == accountID {
+			return true
+		}
+	}
+	return false
+}
+
+// savePasswordFailure stores the failed attempt recorded by checkPassword and returns the check's error
+func savePasswordFailure(collection *mongo.Collection, filter map[string]string, game *Game, checkErr error) error {
+	var stdErr *stderror.WrappedError
+	if errors.As(checkErr, &stdErr) && stdErr.Code == stderror.ErrWrongPassword.Code {
+		if _, err := collection.ReplaceOne(nil, filter, game); err != nil {
+			return err
+		}
+	}
+	return checkErr
+}
+
+// ViewFor returns a copy of the game as seen by a player or spectator.
+// Unless the game shows live paths, players only see their own path while the race is in progress
+// and the click counts of their opponents; spectators and finished games show every path.
+// The IDs of spectators are left out, except the viewer's own, as knowing one would reveal every path.
+func (g *Game) ViewFor(viewerID string) *Game {
+	view := *g
+	view.Players = make([]Player, len(g.Players))
+	hidePaths := g.State == "playing" && !g.LivePaths && g.findSpectator(viewerID) < 0
+	for i, p := range g.Players {
+		p.Clicks = len(p.Paths)
+		if hidePaths && p.ID != viewerID {
+			p.Paths = []string{}
+			p.PathTimes = []time.Time{}
+		}
+		view.Players[i] = p
+	}
+	view.Spectators = make([]Player, len(g.Spectators))
+	for i, s := range g.Spectators {
+		if s.ID != viewerID {
+			s.ID = ""
+		}
+		view.Spectators[i] = s
+	}
+	return &view
+}
+
+// IsMember reports whether a player or spectator is in the game
+func (g *Game) IsMember(playerID string) bool {
+	return g.findPlayer(playerID) >= 0 || g.findSpectator(playerID) >= 0
+}
+
+// Redacted returns a copy of a protected game as seen by a client that has not entered the password:
+// the settings and the names of the players, without their IDs, accounts or paths
+func (g *Game) Redacted() *Game {
+	view := *g
+	view.Players = make([]Player, len(g.Players))
+	for i, p := range g.Players {
+		view.Players[i] = Player{
+			Name:     p.Name,
+			IsLeader: p.IsLeader,
+			IsWinner: p.IsWinner,
+			Bot:      p.Bot,
+			Paths:    []string{},
+			Clicks:   len(p.Paths),
+		}
+	}
+	view.Spectators = []Player{}
+	return &view
+}
+
+// JoinedFrom reports whether a player or spectator joined the game from client
+func (g *Game) JoinedFrom(playerID, client string) bool {
+	if client == "" {
+		return false
+	}
+	if i := g.findPlayer(playerID); i >= 0 {
+		return g.Players[i].Client == client
+	}
+	if i := g.findSpectator(playerID); i >= 0 {
+		return g.Spectators[i].Client == client
+	}
+	return false
+}
+
+// IsLeader reports whether a player leads the game
+func (g *Game) IsLeader(playerID string) bool {
+	i := g.findPlayer(playerID)
+	return i >= 0 && g.Players[i].IsLeader
+}
+
+// CheckLeader returns an error unless a player leads the game
+func (g *Game) CheckLeader(playerID string) error {
+	if !g.IsLeader(playerID) {
+		return stderror.New(stderror.ErrNotLeader, errors.New("player is not the leader, id: "+playerID))
+	}
+	return nil
+}
+
+// findPlayer returns the index of a player in the game, or -1 if the player is not in the game
+func (g *Game) findPlayer(playerID string) int {
+	for i, p := range g.Players {
+		if p.ID == playerID {
+			return i
+		}
+	}
+	return -1
+}
+
+// findSpectator returns the index of a spectator in the game, or -1 if the spectator is not in the game
+func (g *Game) findSpectator(playerID string) int {
+	for i, p := range g.Spectators {
+		if p.ID == playerID {
+			return i
+		}
+	}
+	return -1
+}
+
+// uniqueName returns the name with a numeric suffix if it is already taken by someone in the game
+func uniqueName(g *Game, name string) string {
+	taken := make(map[string]bool, len(g.Players)+len(g.Spectators))
+	for _, p := range append(append([]Player{}, g.Players...), g.Spectators...) {
+		taken[strings.ToLower(p.Name)] = true
+	}
+	if !taken[strings.ToLower(name)] {
+		return name
+	}
+	for i := 2; ; i++ {
+		candidate := fmt.Sprintf("%s (%d)", name, i)
+		if !taken[strings.ToLower(candidate)] {
+			return candidate
+		}
+	}
+}
+
+// GetGame returns a game from the database
+func GetGame(gameCode string, db *mongo.Client) (*Game, error) {
 	// get the game from the database
 	collection := mongodb.GetCollection(db, "wikirace", "games")
 	filter := map[string]string{
@@ -135,24 +533,11 @@ func StartGame(gameCode string, startArticle string, targetArticle string, db *m
 		}
 		return nil, err
 	}
-
-	// update the game state
-	game.State = "playing"
-	game.StartArticle = startArticle
-	game.TargetArticle = targetArticle
-	game.StartTime = time.Now()
-	game.ExpiresAfter = time.Now().Add(expirationTime)
-
-	// update the game in the database
-	_, err = collection.ReplaceOne(nil, filter, game)
-	if err != nil {
-		return nil, err
-	}
 	return &game, nil
 }
 
-// AddPath adds a path to a game and updates the game status accordingly
-func AddPath(gameCode, playerID, path string, db *mongo.Client) (*Game, error) {
+// StartGame starts a game on behalf of its leader
+func StartGame(gameCode, leaderID string, startArticle string, targetArticle string, db *mongo.Client) (*Game, error) {
 	// get the game from the database
 	collection := mongodb.GetCollection(db, "wikirace", "games")
 	filter := map[string]string{
@@ -167,30 +552,15 @@ func AddPath(gameCode, playerID, path string, db *mongo.Client) (*Game, error) {
 		}
 		return nil, err
 	}
-
-	// if game already finished, return the game
-	if game.State == "finished" {
-		return &game, nil
+	if err = game.CheckLeader(leaderID); err != nil {
+		return nil, err
 	}
 
-	// add the path to the player
-	playerFound := false
-	for i, p := range game.Players {
-		if p.ID == playerID {
-			game.Players[i].Paths = append(game.Players[i].Paths, path)
-			playerFound = true
-			// check if the player has reached the target article
-			if path == game.TargetArticle {
-				game.State = "finished"
-				game.EndTime = time.Now()
-				game.Players[i].IsWinner = true
-			}
-			break
-		}
-	}
-	if !playerFound {
-		return nil, stderror.New(stderror.ErrPlayerNotFound, errors.New("player not found, id: "+playerID))
-	}
+	// update the game state
+	game.State = "playing"
+	game.StartArticle = game.titles().Normalize(startArticle)
+	game.TargetArticle = game.titles().Normalize(targetArticle)
+	game.StartTime = time.Now()
 	game.ExpiresAfter = time.Now().Add(expirationTime)
 
 	// update the game in the database
@@ -201,8 +571,97 @@ func AddPath(gameCode, playerID, path string, db *mongo.Client) (*Game, error) {
 	return &game, nil
 }
 
-// ResetGame resets a game to the initial state
-func ResetGame(gameCode string, db *mongo.Client) (*Game, error) {
+// AddPath adds a path to a game and updates the game status accordingly
+func AddPath(gameCode, playerID, path string, db *mongo.Client) (*Game, error) {
+	// the move is written with a single update of the player, so moves of other players and bots
+	// made at the same time are not overwritten. The update only applies to the round that was read,
+	// if the round changed in between the move is tried again on the new one.
+	for attempt := 0; attempt < addPathAttempts; attempt++ {
+		game, err := GetGame(gameCode, db)
+		if err != nil {
+			return nil, err
+		}
+
+		// if game already finished, return the game
+		if game.State == "finished" {
+			return game, nil
+		}
+
+		// spectators only watch the race
+		if game.findSpectator(playerID) >= 0 {
+			return nil, stderror.New(stderror.ErrSpectator, errors.New("spectator cannot add path, id: "+playerID))
+		}
+		if game.findPlayer(playerID) < 0 {
+			return nil, stderror.New(stderror.ErrPlayerNotFound, errors.New("player not found, id: "+playerID))
+		}
+
+		now := time.Now()
+		filter := bson.M{
+			"code":          gameCode,
+			"state":         game.State,
+			"starttime":     game.StartTime,
+			"targetarticle": game.TargetArticle,
+			"players.id":    playerID,
+		}
+		// players that have not moved yet may have no path stored, so the path is appended with $concatArrays
+		// instead of $push, and titles are wrapped in $literal so they are not read as field names
+		move := bson.M{
+			"paths":     bson.M{"$concatArrays": bson.A{bson.M{"$ifNull": bson.A{"$$p.paths", bson.A{}}}, bson.A{bson.M{"$literal": path}}}},
+			"pathtimes": bson.M{"$concatArrays": bson.A{bson.M{"$ifNull": bson.A{"$$p.pathtimes", bson.A{}}}, bson.A{now}}},
+		}
+		set := bson.M{"expiresafter": now.Add(expirationTime)}
+		// check if the player has reached the target article
+		if game.titles().Same(path, game.TargetArticle) {
+			move["iswinner"] = true
+			set["state"] = "finished"
+			set["endtime"] = now
+		}
+		set["players"] = bson.M{"$map": bson.M{
+			"input": "$players",
+			"as":    "p",
+			"in":    bson.M{"$cond": bson.A{bson.M{"$eq": bson.A{"$$p.id", playerID}}, bson.M{"$mergeObjects": bson.A{"$$p", move}}, "$$p"}},
+		}}
+		update := mongo.Pipeline{{{Key: "$set", Value: set}}}
+		opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+		updated := Game{}
+		err = mongodb.GetCollection(db, "wikirace", "games").FindOneAndUpdate(nil, filter, update, opts).Decode(&updated)
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		return &updated, nil
+	}
+	return nil, stderror.New(stderror.ErrServerBusy, errors.New("game changed while adding path, code: "+gameCode))
+}
+
+// VoidWin takes the win of the round started at startTime away from the player with the identity, see Player.Identity.
+// Nothing changes if the game moved on to another round since.
+func VoidWin(gameCode string, startTime time.Time, identity string, db *mongo.Client) error {
+	game, err := GetGame(gameCode, db)
+	if err != nil {
+		return err
+	}
+	if !game.StartTime.Equal(startTime) {
+		return nil
+	}
+	for _, p := range game.Players {
+		if p.Identity() != identity || !p.IsWinner {
+			continue
+		}
+		// only update the winner, so the moves and joins of other players are not overwritten
+		collection := mongodb.GetCollection(db, "wikirace", "games")
+		filter := bson.M{"code": gameCode, "starttime": startTime, "players.id": p.ID}
+		update := bson.M{"$set": bson.M{"players.$.iswinner": false, "players.$.voided": true}}
+		_, err = collection.UpdateOne(nil, filter, update)
+		return err
+	}
+	return nil
+}
+
+// ResetGame resets a game to the initial state on behalf of its leader
+func ResetGame(gameCode, leaderID string, db *mongo.Client) (*Game, error) {
 	// get the game from the database
 	collection := mongodb.GetCollection(db, "wikirace", "games")
 	filter := map[string]string{
@@ -217,6 +676,9 @@ func ResetGame(gameCode string, db *mongo.Client) (*Game, error) {
 		}
 		return nil, err
 	}
+	if err = game.CheckLeader(leaderID); err != nil {
+		return nil, err
+	}
 
 	// reset the game
 	game.State = "waiting"
@@ -227,7 +689,10 @@ func ResetGame(gameCode string, db *mongo.Client) (*Game, error) {
 	game.ExpiresAfter = time.Now().Add(expirationTime)
 	for i := range game.Players {
 		game.Players[i].Paths = []string{}
+		game.Players[i].PathTimes = []time.Time{}
+		game.Players[i].HintsUsed = 0
 		game.Players[i].IsWinner = false
+		game.Players[i].Voided = false
 	}
 
 	// update the game in the database
@@ -238,8 +703,10 @@ func ResetGame(gameCode string, db *mongo.Client) (*Game, error) {
 	return &game, nil
 }
 
-// UpdateGame updates the start and target articles of a game
-func UpdateGame(gameCode, startArticle, targetArticle string, db *mongo.Client) (*Game, error) {
+// UpdateGame updates the start and target articles, the password, the hint rules and the language of a game
+// on behalf of its leader. A nil password keeps the current password, an empty one removes it, nil hint rules
+// or a nil language keep the current ones.
+func UpdateGame(gameCode, leaderID, startArticle, targetArticle string, password *string, hints *HintRules, language *string, db *mongo.Client) (*Game, error) {
 	// get the game from the database
 	collection := mongodb.GetCollection(db, "wikirace", "games")
 	filter := map[string]string{
@@ -254,11 +721,35 @@ func UpdateGame(gameCode, startArticle, targetArticle string, db *mongo.Client)
 		}
 		return nil, err
 	}
+	if err = game.CheckLeader(leaderID); err != nil {
+		return nil, err
+	}
+
+	// changing the hint rules during a race would be unfair to players who already used hints
+	if hints != nil && game.State == "playing" {
+		return nil, stderror.New(stderror.ErrGameInProgress, errors.New("cannot change hint rules while playing, code: "+gameCode))
+	}
+	// the articles players already visited belong to the current language
+	if language != nil && *language != game.Language && game.State == "playing" {
+		return nil, stderror.New(stderror.ErrGameInProgress, errors.New("cannot change language while playing, code: "+gameCode))
+	}
 
 	// update the game
-	game.StartArticle = startArticle
-	game.TargetArticle = targetArticle
+	if hints != nil {
+		game.HintBudget = hints.Budget
+		game.HintPenalty = hints.Penalty
+	}
+	if language != nil {
+		game.Language = *language
+	}
+	game.StartArticle = game.titles().Normalize(startArticle)
+	game.TargetArticle = game.titles().Normalize(targetArticle)
 	game.ExpiresAfter = time.Now().Add(expirationTime)
+	if password != nil {
+		if err = game.setPassword(*password); err != nil {
+			return nil, err
+		}
+	}
 
 	// update the game in the database
 	_, err = collection.ReplaceOne(nil, filter, game)
@@ -268,6 +759,16 @@ func UpdateGame(gameCode, startArticle, targetArticle string, db *mongo.Client)
 	return &game, nil
 }
 
+// DeleteGame removes a game from the database
+func DeleteGame(gameCode string, db *mongo.Client) error {
+	collection := mongodb.GetCollection(db, "wikirace", "games")
+	filter := map[string]string{
+		"code": gameCode,
+	}
+	_, err := collection.DeleteOne(nil, filter)
+	return err
+}
+
 // LeaveGame removes a player from a game
 func LeaveGame(gameCode, playerID string, db *mongo.Client) (*Game, error) {
 	// get the game from the database
@@ -285,21 +786,17 @@ func LeaveGame(gameCode, playerID string, db *mongo.Client) (*Game, error) {
 		return nil, err
 	}
 
-	// remove the player from the game
-	playerFound := false
-	for i, p := range game.Players {
-		if p.ID == playerID {
-			game.Players = append(game.Players[:i], game.Players[i+1:]...)
-			playerFound = true
-			break
-		}
-	}
-	if !playerFound {
+	// remove the player or spectator from the game
+	if i := game.findPlayer(playerID); i >= 0 {
+		game.Players = append(game.Players[:i], game.Players[i+1:]...)
+	} else if i = game.findSpectator(playerID); i >= 0 {
+		game.Spectators = append(game.Spectators[:i], game.Spectators[i+1:]...)
+	} else {
 		return nil, stderror.New(stderror.ErrPlayerNotFound, errors.New("player not found, id: "+playerID))
 	}
 
-	// if the player list is empty, delete the game
-	if len(game.Players) == 0 {
+	// if the player list is empty or only bots are left, delete the game
+	if len(game.Players) == len(game.Bots()) {
 		_, err = collection.DeleteOne(nil, filter)
 		if err != nil {
 			return nil, err
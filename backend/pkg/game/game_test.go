@@ -0,0 +1,81 @@
+package game
+
+import (
+	"testing"
+	"wikirace/pkg/stderror"
+)
+
+func TestRedactedHidesMembers(t *testing.T) {
+	g := &Game{
+		Protected:  true,
+		Players:    []Player{{ID: "p1", AccountID: "a1", Name: "Ada", IsLeader: true, Paths: []string{"A", "B"}}},
+		Spectators: []Player{{ID: "s1", Name: "Bob"}},
+	}
+	view := g.Redacted()
+	p := view.Players[0]
+	if p.ID != "" || p.AccountID != "" || len(p.Paths) != 0 || p.Name != "Ada" || p.Clicks != 2 {
+		t.Fatalf("got player %+v", p)
+	}
+	if len(view.Spectators) != 0 {
+		t.Fatalf("got spectators %+v", view.Spectators)
+	}
+	if g.Players[0].ID != "p1" {
+		t.Fatal("Redacted changed the game")
+	}
+}
+
+func TestViewForHidesSpectatorIDs(t *testing.T) {
+	g := &Game{
+		State:      "playing",
+		Players:    []Player{{ID: "p1", Paths: []string{"A"}}, {ID: "p2", Paths: []string{"A", "B"}}},
+		Spectators: []Player{{ID: "s1", Name: "Bob"}, {ID: "s2", Name: "Eve"}},
+	}
+
+	view := g.ViewFor("p1")
+	if view.Spectators[0].ID != "" || view.Spectators[1].ID != "" {
+		t.Fatalf("player sees spectators %+v", view.Spectators)
+	}
+	if len(view.Players[1].Paths) != 0 || view.Players[1].Clicks != 2 {
+		t.Fatalf("player sees opponent %+v", view.Players[1])
+	}
+
+	view = g.ViewFor("s1")
+	if view.Spectators[0].ID != "s1" || view.Spectators[1].ID != "" {
+		t.Fatalf("spectator sees spectators %+v", view.Spectators)
+	}
+	if len(view.Players[1].Paths) != 2 {
+		t.Fatalf("spectator sees opponent %+v", view.Players[1])
+	}
+	if g.Spectators[1].ID != "s2" {
+		t.Fatal("ViewFor changed the game")
+	}
+}
+
+func TestHasAccount(t *testing.T) {
+	players := []Player{{ID: "p1", Client: "10.0.0.1", AccountID: "a1"}, {ID: "p2", Client: "10.0.0.1"}, {ID: "bot"}}
+	tests := []struct {
+		accountID string
+		want      bool
+	}{
+		{"a1", true},
+		{"a2", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		if got := hasAccount(players, tt.accountID); got != tt.want {
+			t.Errorf("hasAccount(%q) = %v, want %v", tt.accountID, got, tt.want)
+		}
+	}
+}
+
+func TestCheckLeader(t *testing.T) {
+	g := &Game{Players: []Player{{ID: "p1", IsLeader: true}, {ID: "p2"}}, Spectators: []Player{{ID: "s1"}}}
+	if err := g.CheckLeader("p1"); err != nil {
+		t.Fatalf("leader: got %v, want nil", err)
+	}
+	for _, playerID := range []string{"p2", "s1", "unknown", ""} {
+		if err := g.CheckLeader(playerID); errorCode(err) != stderror.ErrNotLeader.Code {
+			t.Errorf("player %q: got %v, want not leader", playerID, err)
+		}
+	}
+}
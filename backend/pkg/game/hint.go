@@ -0,0 +1,80 @@
+package game
+
+import (
+	"errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"time"
+	"wikirace/pkg/logger"
+	"wikirace/pkg/mongodb"
+	"wikirace/pkg/stderror"
+)
+
+// HintRules limit and penalize the hints players use during a round
+type HintRules struct {
+	Budget  int           // hints each player may use per round, zero means no limit, negative disables hints
+	Penalty time.Duration // added to a player's time for every hint used
+}
+
+// CheckHint reports whether a player may use another hint in the current round
+func (g *Game) CheckHint(playerID string) error {
+	if g.findSpectator(playerID) >= 0 {
+		return stderror.New(stderror.ErrSpectator, errors.New("spectator cannot use hints, id: "+playerID))
+	}
+	i := g.findPlayer(playerID)
+	if i < 0 {
+		return stderror.New(stderror.ErrPlayerNotFound, errors.New("player not found, id: "+playerID))
+	}
+	if g.State != "playing" {
+		return stderror.New(stderror.ErrBadRequest, errors.New("game is not in progress, code: "+g.Code))
+	}
+	if g.HintBudget < 0 {
+		return stderror.New(stderror.ErrNoHintsLeft, errors.New("hints are disabled, code: "+g.Code))
+	}
+	if g.HintBudget > 0 && g.Players[i].HintsUsed >= g.HintBudget {
+		return stderror.New(stderror.ErrNoHintsLeft, errors.New("hint budget exhausted, id: "+playerID))
+	}
+	return nil
+}
+
+// UseHint charges a hint to a player's budget
+func UseHint(gameCode, playerID string, db *mongo.Client) (*Game, error) {
+	// get the game from the database
+	collection := mongodb.GetCollection(db, "wikirace", "games")
+	filter := map[string]string{
+		"code": gameCode,
+	}
+	game := Game{}
+	err := collection.FindOne(nil, filter).Decode(&game)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			logger.Debugf("game not found: %v", gameCode)
+			return nil, stderror.New(stderror.ErrGameNotFound, errors.New("game not found, code: "+gameCode))
+		}
+		return nil, err
+	}
+
+	if err = game.CheckHint(playerID); err != nil {
+		return nil, err
+	}
+
+	// only the hint count of the player is changed, so moves made at the same time are not overwritten,
+	// and two hints used at the same time cannot both pass the budget check
+	hintFilter := bson.M{
+		"code":      gameCode,
+		"starttime": game.StartTime,
+		"players":   bson.M{"$elemMatch": bson.M{"id": playerID, "hintsused": game.Players[game.findPlayer(playerID)].HintsUsed}},
+	}
+	update := bson.M{"$inc": bson.M{"players.$.hintsused": 1}}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	updated := Game{}
+	err = collection.FindOneAndUpdate(nil, hintFilter, update, opts).Decode(&updated)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, stderror.New(stderror.ErrServerBusy, errors.New("game changed while using hint, code: "+gameCode))
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
@@ -0,0 +1,72 @@
+package game
+
+import (
+	"errors"
+	"golang.org/x/crypto/bcrypt"
+	"time"
+	"wikirace/pkg/stderror"
+)
+
+const (
+	// maxPasswordFailures wrong passwords of a client within passwordFailureWindow lock the game for that client
+	maxPasswordFailures   = 5
+	passwordFailureWindow = time.Minute
+	maxPasswordBytes      = 72 // bcrypt ignores everything after 72 bytes
+)
+
+// PasswordFailure is a wrong password entered for a game
+type PasswordFailure struct {
+	Client string // IP address of the client that entered the password
+	Time   time.Time
+}
+
+// setPassword stores a salted hash of the password, an empty password removes the protection
+func (g *Game) setPassword(password string) error {
+	g.ClientPasswordFailures = nil
+	if password == "" {
+		g.PasswordHash = ""
+		g.Protected = false
+		return nil
+	}
+	if len(password) > maxPasswordBytes {
+		return stderror.New(stderror.ErrValidation, errors.New("password is too long"))
+	}
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+	g.PasswordHash = string(hash)
+	g.Protected = true
+	return nil
+}
+
+// checkPassword verifies the password of a join attempt by a client. Wrong passwords are recorded on the game,
+// so the caller has to save the game when ErrWrongPassword is returned. Only the client that entered
+// too many wrong passwords is locked out, other clients can still join.
+func (g *Game) checkPassword(password, client string, now time.Time) error {
+	if g.PasswordHash == "" {
+		return nil
+	}
+
+	// only keep the failures within the window
+	failures := make([]PasswordFailure, 0, len(g.ClientPasswordFailures))
+	clientFailures := 0
+	for _, f := range g.ClientPasswordFailures {
+		if now.Sub(f.Time) < passwordFailureWindow {
+			failures = append(failures, f)
+			if f.Client == client {
+				clientFailures++
+			}
+		}
+	}
+	g.ClientPasswordFailures = failures
+	if clientFailures >= maxPasswordFailures {
+		return stderror.New(stderror.ErrTooManyAttempts, errors.New("too many wrong passwords, code: "+g.Code+", client: "+client))
+	}
+
+	if bcrypt.CompareHashAndPassword([]byte(g.PasswordHash), []byte(password)) != nil {
+		g.ClientPasswordFailures = append(g.ClientPasswordFailures, PasswordFailure{Client: client, Time: now})
+		return stderror.New(stderror.ErrWrongPassword, errors.New("wrong password, code: "+g.Code))
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+package game
+
+import (
+	"errors"
+	"testing"
+	"time"
+	"wikirace/pkg/stderror"
+)
+
+func errorCode(err error) int {
+	var stdErr *stderror.WrappedError
+	if errors.As(err, &stdErr) {
+		return stdErr.Code
+	}
+	return 0
+}
+
+func TestCheckPasswordLocksOutClient(t *testing.T) {
+	g := &Game{Code: "ABCDEF"}
+	if err := g.setPassword("secret"); err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+	for i := 0; i < maxPasswordFailures; i++ {
+		if err := g.checkPassword("wrong", "10.0.0.1", now); errorCode(err) != stderror.ErrWrongPassword.Code {
+			t.Fatalf("attempt %d: got %v, want wrong password", i, err)
+		}
+	}
+	if err := g.checkPassword("secret", "10.0.0.1", now); errorCode(err) != stderror.ErrTooManyAttempts.Code {
+		t.Fatalf("locked out client: got %v, want too many attempts", err)
+	}
+	if err := g.checkPassword("secret", "10.0.0.2", now); err != nil {
+		t.Fatalf("other client: got %v, want nil", err)
+	}
+	if err := g.checkPassword("secret", "10.0.0.1", now.Add(passwordFailureWindow)); err != nil {
+		t.Fatalf("after the window: got %v, want nil", err)
+	}
+}
+
+func TestCheckPasswordWithoutProtection(t *testing.T) {
+	g := &Game{Code: "ABCDEF"}
+	if err := g.checkPassword("", "10.0.0.1", time.Now()); err != nil {
+		t.Fatalf("got %v, want nil", err)
+	}
+}
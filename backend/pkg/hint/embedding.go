@@ -0,0 +1,33 @@
+package hint
+
+import (
+	"context"
+	"wikirace/pkg/embedding"
+)
+
+// EmbeddingProvider ranks links by the cosine similarity of their embedding to the target's
+type EmbeddingProvider struct {
+	embeddings *embedding.Cache
+}
+
+// NewEmbeddingProvider creates an EmbeddingProvider looking up the links in the index of embeddings
+func NewEmbeddingProvider(embeddings *embedding.Cache) *EmbeddingProvider {
+	return &EmbeddingProvider{embeddings: embeddings}
+}
+
+func (p *EmbeddingProvider) Name() string {
+	return ProviderEmbedding
+}
+
+func (p *EmbeddingProvider) Suggest(ctx context.Context, req Request) ([]Suggestion, error) {
+	// the target and links are embedded together so a single call is needed for titles not seen before
+	neighbors, err := p.embeddings.Similar(ctx, req.TargetArticle, req.Links, req.Limit)
+	if err != nil {
+		return nil, err
+	}
+	suggestions := make([]Suggestion, 0, len(neighbors))
+	for _, n := range neighbors {
+		suggestions = append(suggestions, Suggestion{Link: n.Title, Score: n.Similarity})
+	}
+	return suggestions, nil
+}
@@ -0,0 +1,150 @@
+package hint
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"wikirace/pkg/stderror"
+)
+
+const (
+	ProviderLexical   = "lexical"   // offline, ranks links by word and spelling overlap with the target
+	ProviderEmbedding = "embedding" // ranks links by embedding similarity to the target
+	ProviderLLM       = "llm"       // asks a language model for the most promising links
+
+	DefaultLimit = 5
+	MaxLimit     = 20
+)
+
+// Request describes the position of a player asking for a hint
+type Request struct {
+	CurrentArticle string
+	TargetArticle  string
+	Language       string   // language of the Wikipedia the articles belong to, empty for English
+	Links          []string // links on the current article
+	Limit          int      // maximum number of suggestions, DefaultLimit if not positive
+}
+
+// Suggestion is a link recommended to reach the target
+type Suggestion struct {
+	Link      string  `json:"link"`
+	Score     float64 `json:"score"`               // higher is better, only comparable within a provider
+	Reasoning string  `json:"reasoning,omitempty"` // short explanation, if the provider gives one
+}
+
+// Provider ranks the links of an article by how likely they lead to the target
+type Provider interface {
+	// Name returns the name the provider is selected by
+	Name() string
+	// Suggest returns at most req.Limit suggestions, best first
+	Suggest(ctx context.Context, req Request) ([]Suggestion, error)
+}
+
+// Service selects a provider for each hint request
+type Service struct {
+	providers       map[string]Provider
+	defaultProvider string
+}
+
+// NewService creates a service using defaultProvider when a request does not name one
+func NewService(defaultProvider string, providers ...Provider) *Service {
+	s := &Service{
+		providers:       make(map[string]Provider),
+		defaultProvider: defaultProvider,
+	}
+	for _, p := range providers {
+		s.providers[p.Name()] = p
+	}
+	return s
+}
+
+// Providers returns the names of the registered providers, sorted
+func (s *Service) Providers() []string {
+	names := make([]string, 0, len(s.providers))
+	for name := range s.providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Provider returns a registered provider, an empty name selects the default one
+func (s *Service) Provider(name string) (Provider, error) {
+	if name == "" {
+		name = s.defaultProvider
+	}
+	p, ok := s.providers[name]
+	if !ok {
+		return nil, stderror.New(stderror.ErrBadRequest, errors.New("unknown hint provider: "+name))
+	}
+	return p, nil
+}
+
+// Suggest normalizes a request and asks the named provider for suggestions
+func (s *Service) Suggest(ctx context.Context, providerName string, req Request) ([]Suggestion, error) {
+	p, err := s.Provider(providerName)
+	if err != nil {
+		return nil, err
+	}
+	req = normalize(req)
+	if len(req.Links) == 0 {
+		return []Suggestion{}, nil
+	}
+	suggestions, err := p.Suggest(ctx, req)
+	if err != nil {
+		return nil, stderror.New(stderror.ErrAPI, err)
+	}
+	return filter(suggestions, req), nil
+}
+
+// normalize clamps the limit and drops blank, duplicate and already visited links
+func normalize(req Request) Request {
+	if req.Limit <= 0 {
+		req.Limit = DefaultLimit
+	}
+	req.Limit = min(req.Limit, MaxLimit)
+	seen := map[string]bool{req.CurrentArticle: true}
+	links := make([]string, 0, len(req.Links))
+	for _, link := range req.Links {
+		link = strings.TrimSpace(link)
+		if link == "" || seen[link] {
+			continue
+		}
+		seen[link] = true
+		links = append(links, link)
+	}
+	req.Links = links
+	return req
+}
+
+// filter drops suggestions that are not links of the request, so providers cannot
+// send players to arbitrary articles, and applies the limit
+func filter(suggestions []Suggestion, req Request) []Suggestion {
+	valid := make(map[string]bool, len(req.Links))
+	for _, link := range req.Links {
+		valid[link] = true
+	}
+	result := make([]Suggestion, 0, req.Limit)
+	for _, s := range suggestions {
+		if !valid[s.Link] {
+			continue
+		}
+		valid[s.Link] = false
+		result = append(result, s)
+		if len(result) == req.Limit {
+			break
+		}
+	}
+	return result
+}
+
+// rank sorts suggestions by score, ties are broken by link so results are deterministic
+func rank(suggestions []Suggestion) {
+	sort.SliceStable(suggestions, func(i, j int) bool {
+		if suggestions[i].Score != suggestions[j].Score {
+			return suggestions[i].Score > suggestions[j].Score
+		}
+		return suggestions[i].Link < suggestions[j].Link
+	})
+}
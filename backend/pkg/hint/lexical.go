@@ -0,0 +1,144 @@
+package hint
+
+import (
+	"context"
+	"strings"
+	"unicode"
+)
+
+// stopwords are ignored when comparing titles, by language
+var stopwords = map[string]map[string]bool{
+	"en": {
+		"a": true, "an": true, "and": true, "at": true, "by": true, "for": true, "in": true,
+		"of": true, "on": true, "or": true, "the": true, "to": true, "with": true,
+	},
+	"de": {
+		"am": true, "an": true, "auf": true, "der": true, "die": true, "das": true, "des": true,
+		"ein": true, "eine": true, "im": true, "in": true, "mit": true, "und": true, "von": true, "zu": true,
+	},
+	"fr": {
+		"à": true, "au": true, "aux": true, "d": true, "de": true, "des": true, "du": true, "en": true,
+		"et": true, "l": true, "la": true, "le": true, "les": true, "un": true, "une": true,
+	},
+	"es": {
+		"a": true, "al": true, "de": true, "del": true, "el": true, "en": true, "la": true,
+		"las": true, "los": true, "por": true, "un": true, "una": true, "y": true,
+	},
+}
+
+// stopwordsFor returns the stopwords of a language, English if the language is empty
+func stopwordsFor(language string) map[string]bool {
+	if language == "" {
+		language = "en"
+	}
+	return stopwords[language]
+}
+
+// LexicalProvider ranks links by how much their titles overlap with the target title,
+// it needs no network access and always returns the same suggestions for the same request
+type LexicalProvider struct{}
+
+// NewLexicalProvider creates a LexicalProvider
+func NewLexicalProvider() *LexicalProvider {
+	return &LexicalProvider{}
+}
+
+func (p *LexicalProvider) Name() string {
+	return ProviderLexical
+}
+
+func (p *LexicalProvider) Suggest(_ context.Context, req Request) ([]Suggestion, error) {
+	if strings.EqualFold(req.CurrentArticle, req.TargetArticle) {
+		return []Suggestion{}, nil
+	}
+	ignored := stopwordsFor(req.Language)
+	targetWords := words(req.TargetArticle, ignored)
+	targetGrams := trigrams(req.TargetArticle)
+	suggestions := make([]Suggestion, 0, len(req.Links))
+	for _, link := range req.Links {
+		if strings.EqualFold(link, req.TargetArticle) {
+			suggestions = append(suggestions, Suggestion{Link: link, Score: 1, Reasoning: "This is the target article."})
+			continue
+		}
+		linkWords := words(link, ignored)
+		shared := intersect(linkWords, targetWords)
+		// shared words matter most, spelling similarity catches plurals and compounds
+		score := 0.7*jaccard(linkWords, targetWords) + 0.3*dice(trigrams(link), targetGrams)
+		if score == 0 {
+			continue
+		}
+		reasoning := "Its title is spelled similarly to the target."
+		if len(shared) > 0 {
+			reasoning = "Shares words with the target: " + strings.Join(shared, ", ") + "."
+		}
+		suggestions = append(suggestions, Suggestion{Link: link, Score: score, Reasoning: reasoning})
+	}
+	rank(suggestions)
+	return suggestions, nil
+}
+
+// words splits a title into its lowercase words without stopwords
+func words(title string, stopwords map[string]bool) []string {
+	fields := strings.FieldsFunc(strings.ToLower(title), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	result := make([]string, 0, len(fields))
+	seen := make(map[string]bool)
+	for _, f := range fields {
+		if stopwords[f] || seen[f] {
+			continue
+		}
+		seen[f] = true
+		result = append(result, f)
+	}
+	return result
+}
+
+// trigrams returns the set of character trigrams of a lowercase title
+func trigrams(title string) map[string]bool {
+	runes := []rune(" " + strings.ToLower(title) + " ")
+	grams := make(map[string]bool)
+	for i := 0; i+3 <= len(runes); i++ {
+		grams[string(runes[i:i+3])] = true
+	}
+	return grams
+}
+
+// intersect returns the words of a that are also in b, in the order of a
+func intersect(a, b []string) []string {
+	inB := make(map[string]bool, len(b))
+	for _, w := range b {
+		inB[w] = true
+	}
+	shared := make([]string, 0)
+	for _, w := range a {
+		if inB[w] {
+			shared = append(shared, w)
+		}
+	}
+	return shared
+}
+
+// jaccard is the size of the intersection divided by the size of the union of two word sets
+func jaccard(a, b []string) float64 {
+	shared := len(intersect(a, b))
+	union := len(a) + len(b) - shared
+	if union == 0 {
+		return 0
+	}
+	return float64(shared) / float64(union)
+}
+
+// dice is the Sørensen–Dice coefficient of two trigram sets
+func dice(a, b map[string]bool) float64 {
+	if len(a)+len(b) == 0 {
+		return 0
+	}
+	shared := 0
+	for g := range a {
+		if b[g] {
+			shared++
+		}
+	}
+	return 2 * float64(shared) / float64(len(a)+len(b))
+}
@@ -0,0 +1,84 @@
+package hint
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Completer answers a prompt with a language model
+type Completer interface {
+	Complete(ctx context.Context, prompt string) (string, error)
+}
+
+// LLMProvider asks a language model which links are the most promising
+type LLMProvider struct {
+	completer Completer
+}
+
+// NewLLMProvider creates an LLMProvider using the given completer
+func NewLLMProvider(completer Completer) *LLMProvider {
+	return &LLMProvider{completer: completer}
+}
+
+func (p *LLMProvider) Name() string {
+	return ProviderLLM
+}
+
+type llmSuggestions struct {
+	Suggestions []struct {
+		Link      string `json:"link"`
+		Reasoning string `json:"reasoning"`
+	} `json:"suggestions"`
+}
+
+func (p *LLMProvider) Suggest(ctx context.Context, req Request) ([]Suggestion, error) {
+	answer, err := p.completer.Complete(ctx, prompt(req))
+	if err != nil {
+		return nil, err
+	}
+	var parsed llmSuggestions
+	if err := json.Unmarshal([]byte(stripCodeFence(answer)), &parsed); err != nil {
+		return nil, fmt.Errorf("parse model answer: %w", err)
+	}
+	// the model orders its suggestions, so earlier ones score higher
+	suggestions := make([]Suggestion, 0, len(parsed.Suggestions))
+	for i, s := range parsed.Suggestions {
+		suggestions = append(suggestions, Suggestion{
+			Link:      s.Link,
+			Score:     float64(len(parsed.Suggestions) - i),
+			Reasoning: s.Reasoning,
+		})
+	}
+	return suggestions, nil
+}
+
+// prompt builds the instructions sent to the model
+func prompt(req Request) string {
+	language := req.Language
+	if language == "" {
+		language = "en"
+	}
+	return fmt.Sprintf(`You are playing a game where you need to navigate from one article of the Wikipedia in language %q to another using only the links in each article.
+You are currently on the article %q and need to reach the article %q.
+These are the links available on the current article:
+%s
+
+Pick up to %d links that are most likely to lead to the target article, best first, and briefly explain each choice.
+Only pick links from the list above, using their exact names.
+Respond with JSON only, in the format {"suggestions": [{"link": "...", "reasoning": "..."}]}`,
+		language, req.CurrentArticle, req.TargetArticle, strings.Join(req.Links, "\n"), req.Limit)
+}
+
+// stripCodeFence removes the markdown code fence models tend to wrap JSON in
+func stripCodeFence(s string) string {
+	s = strings.TrimSpace(s)
+	if !strings.HasPrefix(s, "```") {
+		return s
+	}
+	s = strings.TrimPrefix(s, "```")
+	s = strings.TrimPrefix(s, "json")
+	s = strings.TrimSuffix(strings.TrimSpace(s), "```")
+	return strings.TrimSpace(s)
+}
@@ -0,0 +1,38 @@
+package hint
+
+import (
+	"context"
+	"wikirace/pkg/openai"
+)
+
+// OpenAIEmbedder is an embedding.Embedder backed by the OpenAI embeddings API
+type OpenAIEmbedder struct {
+	client *openai.Client
+	model  string
+}
+
+// NewOpenAIEmbedder creates an OpenAIEmbedder using the given model
+func NewOpenAIEmbedder(client *openai.Client, model string) *OpenAIEmbedder {
+	return &OpenAIEmbedder{client: client, model: model}
+}
+
+func (e *OpenAIEmbedder) Embed(ctx context.Context, texts []string) ([][]float64, error) {
+	return e.client.Embeddings(ctx, e.model, texts)
+}
+
+// OpenAICompleter is a Completer backed by the OpenAI chat completions API
+type OpenAICompleter struct {
+	client      *openai.Client
+	model       string
+	temperature float64
+}
+
+// NewOpenAICompleter creates an OpenAICompleter using the given model
+func NewOpenAICompleter(client *openai.Client, model string, temperature float64) *OpenAICompleter {
+	return &OpenAICompleter{client: client, model: model, temperature: temperature}
+}
+
+func (c *OpenAICompleter) Complete(ctx context.Context, prompt string) (string, error) {
+	messages := []openai.Message{{Role: "user", Content: prompt}}
+	return c.client.ChatCompletion(ctx, c.model, messages, c.temperature)
+}
@@ -0,0 +1,102 @@
+package apiv1
+
+import (
+	"math"
+	"wikirace/pkg/account"
+	"wikirace/pkg/logic"
+)
+
+// resolvePlayerName checks that the player owns the optional account and returns the name the player joins a game with,
+// the account's display name is used when no name is given
+func resolvePlayerName(app logic.Application, accountID, accountToken, name string) (string, error) {
+	if accountID == "" {
+		return name, nil
+	}
+	a, err := ownedAccount(app, accountID, accountToken)
+	if err != nil {
+		return "", err
+	}
+	if name == "" {
+		return a.DisplayName, nil
+	}
+	return name, nil
+}
+
+// ownedAccount returns an account if the token proves that the caller owns it
+func ownedAccount(app logic.Application, accountID, accountToken string) (*account.Account, error) {
+	a, err := app.GetAccountStore().Get(accountID)
+	if err != nil {
+		return nil, err
+	}
+	if err = a.CheckToken(accountToken); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+type CreateAccountRequest struct {
+	DisplayName string `json:"displayName"`
+	AvatarURL   string `json:"avatarURL"`
+}
+
+type CreateAccountResponse struct {
+	Account *account.Account `json:"account"`
+	Token   string           `json:"token"` // secret proving ownership of the account, only returned once
+}
+
+// CreateAccount implements /api/v1/accounts/create
+func CreateAccount(app logic.Application, req CreateAccountRequest) (interface{}, error) {
+	a, token, err := account.New(req.DisplayName, req.AvatarURL)
+	if err != nil {
+		return nil, err
+	}
+	if err = app.GetAccountStore().Create(a); err != nil {
+		return nil, err
+	}
+	return CreateAccountResponse{Account: a, Token: token}, nil
+}
+
+type AccountProfileResponse struct {
+	Account *account.Account `json:"account"`
+	Rating  int              `json:"rating"`
+	Played  int              `json:"played"` // number of rated rounds
+}
+
+// GetAccount implements /api/v1/accounts/info
+func GetAccount(app logic.Application, accountID string) (interface{}, error) {
+	a, err := app.GetAccountStore().Get(accountID)
+	if err != nil {
+		return nil, err
+	}
+	ratings, err := app.GetRatingStore().Get([]string{a.ID})
+	if err != nil {
+		return nil, err
+	}
+	return AccountProfileResponse{
+		Account: a,
+		Rating:  int(math.Round(ratings[a.ID].Rating)),
+		Played:  ratings[a.ID].Played,
+	}, nil
+}
+
+type UpdateAccountRequest struct {
+	AccountID    string `json:"accountID"`
+	AccountToken string `json:"accountToken"`
+	DisplayName  string `json:"displayName"`
+	AvatarURL    string `json:"avatarURL"`
+}
+
+// UpdateAccount implements /api/v1/accounts/update
+func UpdateAccount(app logic.Application, req UpdateAccountRequest) (interface{}, error) {
+	a, err := ownedAccount(app, req.AccountID, req.AccountToken)
+	if err != nil {
+		return nil, err
+	}
+	if err = a.SetProfile(req.DisplayName, req.AvatarURL); err != nil {
+		return nil, err
+	}
+	if err = app.GetAccountStore().Update(a); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
@@ -1,8 +1,12 @@
 package apiv1
 
 import (
+	"errors"
+	"time"
 	"wikirace/pkg/game"
 	"wikirace/pkg/logic"
+	"wikirace/pkg/stderror"
+	"wikirace/pkg/wiki"
 )
 
 // HealthCheck implements /api/v1/ping
@@ -11,8 +15,22 @@ func HealthCheck() (interface{}, error) {
 }
 
 type CreateGameRequest struct {
-	LeaderName string `json:"leaderName"`
-	PlayerID   string `json:"playerID"`
+	LeaderName         string `json:"leaderName"`
+	PlayerID           string `json:"playerID"`
+	AccountID          string `json:"accountID"`          // optional, links the player to a persistent account
+	AccountToken       string `json:"accountToken"`       // required with accountID, proves the player owns the account
+	Type               string `json:"type"`               // "multiplayer" (default) or "solo"
+	Public             bool   `json:"public"`             // list the lobby in the lobby browser
+	MaxPlayers         int    `json:"maxPlayers"`         // zero uses the server limit
+	MidGameJoin        string `json:"midGameJoin"`        // "reject", "spectate" or "late", empty uses the server default
+	Password           string `json:"password"`           // optional lobby password
+	LivePaths          bool   `json:"livePaths"`          // show opponents' paths while the race is in progress
+	HintBudget         *int   `json:"hintBudget"`         // hints per player and round, zero means no limit, negative disables hints, omit to use the server default
+	HintPenaltySeconds *int   `json:"hintPenaltySeconds"` // time added for every hint used, omit to use the server default
+	Language           string `json:"language"`           // Wikipedia the game is played on, e.g. "de", empty uses the server default
+	StartArticle       string `json:"startArticle"`       // solo games only, chosen by the server if empty
+	TargetArticle      string `json:"targetArticle"`      // solo games only, chosen by the server if empty
+	ClientIP           string `json:"-"`                  // recorded on the leader, see game.Player.Client
 }
 
 type CreateGameResponse struct {
@@ -21,13 +39,115 @@ type CreateGameResponse struct {
 
 // CreateGame implements /api/v1/games/create
 func CreateGame(app logic.Application, req CreateGameRequest) (interface{}, error) {
-	return game.CreateGame(req.LeaderName, req.PlayerID, app.GetMongoDB())
+	name, err := resolvePlayerName(app, req.AccountID, req.AccountToken, req.LeaderName)
+	if err != nil {
+		return nil, err
+	}
+	w, err := languageWiki(app, req.Language)
+	if err != nil {
+		return nil, err
+	}
+	switch req.Type {
+	case "", game.TypeMultiplayer:
+		settings, err := gameSettings(app, req.Public, req.MaxPlayers, req.MidGameJoin)
+		if err != nil {
+			return nil, err
+		}
+		settings.Password = req.Password
+		settings.LivePaths = req.LivePaths
+		settings.Language = w.Language()
+		if settings.Hints, err = hintRules(app, req.HintBudget, req.HintPenaltySeconds); err != nil {
+			return nil, err
+		}
+		return game.CreateGame(name, req.PlayerID, req.AccountID, req.ClientIP, settings, app.GetMongoDB())
+	case game.TypeSolo:
+		return createSoloGame(app, w, req, name)
+	default:
+		return nil, stderror.New(stderror.ErrValidation, errors.New("unknown game type: "+req.Type))
+	}
+}
+
+// gameSettings validates the settings requested by a leader against the server configuration
+func gameSettings(app logic.Application, public bool, maxPlayers int, midGameJoin string) (game.Settings, error) {
+	config := app.GetConfig().Game
+	if maxPlayers < 0 {
+		return game.Settings{}, stderror.New(stderror.ErrValidation, errors.New("negative player limit"))
+	}
+	if maxPlayers == 0 || (config.MaxPlayers > 0 && maxPlayers > config.MaxPlayers) {
+		maxPlayers = config.MaxPlayers
+	}
+	if midGameJoin == "" {
+		midGameJoin = config.MidGameJoin
+	}
+	switch midGameJoin {
+	case "", game.MidGameJoinReject, game.MidGameJoinSpectate, game.MidGameJoinLate:
+	default:
+		return game.Settings{}, stderror.New(stderror.ErrValidation, errors.New("unknown mid-game join policy: "+midGameJoin))
+	}
+	return game.Settings{
+		Public:      public,
+		MaxPlayers:  maxPlayers,
+		MidGameJoin: midGameJoin,
+	}, nil
+}
+
+// hintRules validates the hint rules requested by a leader, omitted values use the server configuration
+func hintRules(app logic.Application, budget, penaltySeconds *int) (game.HintRules, error) {
+	config := app.GetConfig().Hints
+	rules := game.HintRules{
+		Budget:  config.Budget,
+		Penalty: time.Duration(config.PenaltySeconds) * time.Second,
+	}
+	if budget != nil {
+		rules.Budget = *budget
+	}
+	if penaltySeconds != nil {
+		if *penaltySeconds < 0 {
+			return game.HintRules{}, stderror.New(stderror.ErrValidation, errors.New("negative hint penalty"))
+		}
+		rules.Penalty = time.Duration(*penaltySeconds) * time.Second
+	}
+	return rules, nil
+}
+
+// createSoloGame starts a practice round right away, with the given articles or a random pair:
+// from the daily pool in the default language, or drawn from the wiki in other languages
+func createSoloGame(app logic.Application, w *wiki.Service, req CreateGameRequest, name string) (*game.Game, error) {
+	if (req.StartArticle == "") != (req.TargetArticle == "") {
+		return nil, stderror.New(stderror.ErrValidation, errors.New("start and target articles must be given together"))
+	}
+	start, target := req.StartArticle, req.TargetArticle
+	switch {
+	case start != "":
+		start, target = canonicalTitle(w, start), canonicalTitle(w, target)
+		if err := validateArticles(w, start, target, true); err != nil {
+			return nil, err
+		}
+	case w.Language() == app.GetWikis().DefaultLanguage():
+		pair := dailyPicker(app).Random()
+		start, target = pair.StartArticle, pair.TargetArticle
+	default:
+		var err error
+		if start, target, err = randomPair(w); err != nil {
+			return nil, err
+		}
+	}
+	hints, err := hintRules(app, req.HintBudget, req.HintPenaltySeconds)
+	if err != nil {
+		return nil, err
+	}
+	return game.CreateSoloGame(name, req.PlayerID, req.AccountID, req.ClientIP, w.Language(), start, target, hints, app.GetMongoDB())
 }
 
 type JoinGameRequest struct {
-	GameCode   string `json:"gameCode"`
-	PlayerID   string `json:"playerID"`
-	PlayerName string `json:"playerName"`
+	GameCode     string `json:"gameCode"`
+	PlayerID     string `json:"playerID"`
+	PlayerName   string `json:"playerName"`
+	AccountID    string `json:"accountID"`    // optional, links the player to a persistent account
+	AccountToken string `json:"accountToken"` // required with accountID, proves the player owns the account
+	Spectator    bool   `json:"spectator"`    // watch the game instead of playing
+	Password     string `json:"password"`     // required if the lobby is password protected
+	ClientIP     string `json:"-"`            // wrong passwords lock out the client that entered them
 }
 
 type JoinGameResponse struct {
@@ -36,16 +156,46 @@ type JoinGameResponse struct {
 
 // JoinGame implements /api/v1/games/join
 func JoinGame(app logic.Application, req JoinGameRequest) (interface{}, error) {
-	return game.JoinGame(req.GameCode, req.PlayerID, req.PlayerName, app.GetMongoDB())
+	name, err := resolvePlayerName(app, req.AccountID, req.AccountToken, req.PlayerName)
+	if err != nil {
+		return nil, err
+	}
+	if req.Spectator {
+		return viewFor(req.PlayerID)(game.SpectateGame(req.GameCode, req.PlayerID, req.AccountID, name, req.Password, req.ClientIP, app.GetMongoDB()))
+	}
+	return viewFor(req.PlayerID)(game.JoinGame(req.GameCode, req.PlayerID, req.AccountID, name, req.Password, req.ClientIP, app.GetMongoDB()))
+}
+
+// viewFor shapes the game returned by a game function for the requesting player, see game.Game.ViewFor
+func viewFor(viewerID string) func(*game.Game, error) (interface{}, error) {
+	return func(g *game.Game, err error) (interface{}, error) {
+		if err != nil {
+			return nil, err
+		}
+		if g == nil {
+			return nil, nil
+		}
+		return g.ViewFor(viewerID), nil
+	}
 }
 
 // GetGame implements /api/v1/games/info
-func GetGame(app logic.Application, gameCode string) (interface{}, error) {
-	return game.GetGame(gameCode, app.GetMongoDB())
+func GetGame(app logic.Application, gameCode, playerID string) (interface{}, error) {
+	g, err := game.GetGame(gameCode, app.GetMongoDB())
+	if err != nil {
+		return nil, err
+	}
+	// protected games only show their players to the players and spectators who entered the password
+	if g.Protected && !g.IsMember(playerID) {
+		return g.Redacted(), nil
+	}
+	attachRatings(app, g)
+	return g.ViewFor(playerID), nil
 }
 
 type StartGameRequest struct {
 	GameCode      string `json:"gameCode"`
+	PlayerID      string `json:"playerID"` // must be the leader of the game
 	StartArticle  string `json:"startArticle"`
 	TargetArticle string `json:"targetArticle"`
 }
@@ -56,7 +206,27 @@ type StartGameResponse struct {
 
 // StartGame implements /api/v1/games/start
 func StartGame(app logic.Application, req StartGameRequest) (interface{}, error) {
-	return game.StartGame(req.GameCode, req.StartArticle, req.TargetArticle, app.GetMongoDB())
+	g, err := game.GetGame(req.GameCode, app.GetMongoDB())
+	if err != nil {
+		return nil, err
+	}
+	if err = g.CheckLeader(req.PlayerID); err != nil {
+		return nil, err
+	}
+	w, err := gameWiki(app, g)
+	if err != nil {
+		return nil, err
+	}
+	start, target := canonicalTitle(w, req.StartArticle), canonicalTitle(w, req.TargetArticle)
+	if err := validateArticles(w, start, target, true); err != nil {
+		return nil, err
+	}
+	g, err = game.StartGame(req.GameCode, req.PlayerID, start, target, app.GetMongoDB())
+	if err != nil {
+		return nil, err
+	}
+	startBots(app, g)
+	return g.ViewFor(""), nil
 }
 
 type AddPathRequest struct {
@@ -71,11 +241,34 @@ type AddPathResponse struct {
 
 // AddPath implements /api/v1/games/addpath
 func AddPath(app logic.Application, req AddPathRequest) (interface{}, error) {
-	return game.AddPath(req.GameCode, req.PlayerID, req.ArticleName, app.GetMongoDB())
+	g, err := addPath(app, req)
+	if err != nil {
+		return nil, err
+	}
+	return g.ViewFor(req.PlayerID), nil
+}
+
+// addPath records a move of a human or bot player and archives the round if the move won it
+func addPath(app logic.Application, req AddPathRequest) (*game.Game, error) {
+	g, err := game.GetGame(req.GameCode, app.GetMongoDB())
+	if err != nil {
+		return nil, err
+	}
+	w, err := gameWiki(app, g)
+	if err != nil {
+		return nil, err
+	}
+	g, err = game.AddPath(req.GameCode, req.PlayerID, canonicalTitle(w, req.ArticleName), app.GetMongoDB())
+	if err != nil {
+		return nil, err
+	}
+	archiveFinishedGame(app, g)
+	return g, nil
 }
 
 type ResetGameRequest struct {
 	GameCode string `json:"gameCode"`
+	PlayerID string `json:"playerID"` // must be the leader of the game
 }
 
 type ResetGameResponse struct {
@@ -84,13 +277,28 @@ type ResetGameResponse struct {
 
 // ResetGame implements /api/v1/games/reset
 func ResetGame(app logic.Application, req ResetGameRequest) (interface{}, error) {
-	return game.ResetGame(req.GameCode, app.GetMongoDB())
+	g, err := game.GetGame(req.GameCode, app.GetMongoDB())
+	if err != nil {
+		return nil, err
+	}
+	if err = g.CheckLeader(req.PlayerID); err != nil {
+		return nil, err
+	}
+	// archive the round before its results are cleared
+	archiveFinishedGame(app, g)
+	return viewFor("")(game.ResetGame(req.GameCode, req.PlayerID, app.GetMongoDB()))
 }
 
 type UpdateGameRequest struct {
-	GameCode      string `json:"gameCode"`
-	StartArticle  string `json:"startArticle"`
-	TargetArticle string `json:"targetArticle"`
+	GameCode           string  `json:"gameCode"`
+	PlayerID           string  `json:"playerID"` // must be the leader of the game
+	StartArticle       string  `json:"startArticle"`
+	TargetArticle      string  `json:"targetArticle"`
+	Password           *string `json:"password"`   // omit to keep the current password, empty to remove it
+	HintBudget         *int    `json:"hintBudget"` // omit both hint settings to keep the current hint rules
+	HintPenaltySeconds *int    `json:"hintPenaltySeconds"`
+	Language           *string `json:"language"` // omit to keep the current language, the articles must belong to the new one
+	PoolID             string  `json:"poolID"`   // optional, fills empty start and target articles with random articles of the pool
 }
 
 type UpdateGameResponse struct {
@@ -99,7 +307,56 @@ type UpdateGameResponse struct {
 
 // UpdateGame implements /api/v1/games/update
 func UpdateGame(app logic.Application, req UpdateGameRequest) (interface{}, error) {
-	return game.UpdateGame(req.GameCode, req.StartArticle, req.TargetArticle, app.GetMongoDB())
+	g, err := game.GetGame(req.GameCode, app.GetMongoDB())
+	if err != nil {
+		return nil, err
+	}
+	if err = g.CheckLeader(req.PlayerID); err != nil {
+		return nil, err
+	}
+	var hints *game.HintRules
+	if req.HintBudget != nil || req.HintPenaltySeconds != nil {
+		// an omitted hint setting keeps its current value
+		budget, penaltySeconds := g.HintBudget, int(g.HintPenalty/time.Second)
+		if req.HintBudget != nil {
+			budget = *req.HintBudget
+		}
+		if req.HintPenaltySeconds != nil {
+			penaltySeconds = *req.HintPenaltySeconds
+		}
+		rules, err := hintRules(app, &budget, &penaltySeconds)
+		if err != nil {
+			return nil, err
+		}
+		hints = &rules
+	}
+	language := g.Language
+	if req.Language != nil {
+		language = *req.Language
+	}
+	w, err := languageWiki(app, language)
+	if err != nil {
+		return nil, err
+	}
+	var newLanguage *string
+	if req.Language != nil {
+		// store the resolved language, so an empty one selects the server default
+		resolved := w.Language()
+		newLanguage = &resolved
+	}
+	var start, target string
+	if req.PoolID != "" {
+		// the drawn articles are validated together with the given ones
+		if start, target, err = poolArticles(app, w, req.PoolID, req.StartArticle, req.TargetArticle); err != nil {
+			return nil, err
+		}
+	} else {
+		start, target = canonicalTitle(w, req.StartArticle), canonicalTitle(w, req.TargetArticle)
+		if err := validateArticles(w, start, target, false); err != nil {
+			return nil, err
+		}
+	}
+	return viewFor("")(game.UpdateGame(req.GameCode, req.PlayerID, start, target, req.Password, hints, newLanguage, app.GetMongoDB()))
 }
 
 type LeaveGameRequest struct {
@@ -113,5 +370,9 @@ type LeaveGameResponse struct {
 
 // LeaveGame implements /api/v1/games/leave
 func LeaveGame(app logic.Application, req LeaveGameRequest) (interface{}, error) {
-	return game.LeaveGame(req.GameCode, req.PlayerID, app.GetMongoDB())
+	// archive the round before the game is deleted when the last player leaves
+	if g, err := game.GetGame(req.GameCode, app.GetMongoDB()); err == nil {
+		archiveFinishedGame(app, g)
+	}
+	return viewFor(req.PlayerID)(game.LeaveGame(req.GameCode, req.PlayerID, app.GetMongoDB()))
 }
@@ -0,0 +1,140 @@
+package apiv1
+
+import (
+	"errors"
+	"fmt"
+	"time"
+	"wikirace/pkg/bot"
+	"wikirace/pkg/game"
+	"wikirace/pkg/logger"
+	"wikirace/pkg/logic"
+	"wikirace/pkg/stderror"
+)
+
+// maxBotsPerRequest bounds the bots added by one request
+const maxBotsPerRequest = 16
+
+type AddBotRequest struct {
+	GameCode string `json:"gameCode"`
+	PlayerID string `json:"playerID"` // must be the leader of the game
+	Strategy string `json:"strategy"` // see ListBotStrategies
+	Name     string `json:"name"`     // defaults to "Bot", numbered if taken
+	DelayMs  int    `json:"delayMs"`  // average time per move, at least bots.minDelayMs, zero uses the server default
+	Count    int    `json:"count"`    // number of bots to add, defaults to 1
+}
+
+type AddBotResponse struct {
+	Game game.Game     `json:"game"`
+	Bots []game.Player `json:"bots"` // the added bots
+}
+
+// AddBot implements /api/v1/bots/add
+func AddBot(app logic.Application, req AddBotRequest) (interface{}, error) {
+	if req.DelayMs < 0 {
+		return nil, stderror.New(stderror.ErrValidation, errors.New("negative bot delay"))
+	}
+	// fast bots win instantly and flood the game with moves
+	if minDelay := app.GetConfig().Bots.MinDelayMs; req.DelayMs > 0 && req.DelayMs < minDelay {
+		return nil, stderror.New(stderror.ErrValidation, fmt.Errorf("bot delay below %d ms", minDelay))
+	}
+	if req.Count == 0 {
+		req.Count = 1
+	}
+	if req.Count < 0 || req.Count > maxBotsPerRequest {
+		return nil, stderror.New(stderror.ErrValidation, errors.New("too many bots requested"))
+	}
+	// reject strategies the server cannot play before any bot joins
+	if _, err := bot.New(req.Strategy, nil, app.GetHintService(), 0); err != nil {
+		return nil, err
+	}
+
+	settings := game.BotSettings{Strategy: req.Strategy, Delay: time.Duration(req.DelayMs) * time.Millisecond}
+	var g *game.Game
+	added := make([]game.Player, 0, req.Count)
+	for i := 0; i < req.Count; i++ {
+		var p *game.Player
+		var err error
+		if g, p, err = game.AddBot(req.GameCode, req.PlayerID, req.Name, settings, app.GetConfig().Bots.MaxPerGame, app.GetMongoDB()); err != nil {
+			return nil, err
+		}
+		added = append(added, *p)
+		// bots joining a solo game race right away
+		if g.State == "playing" {
+			startBot(app, g, *p)
+		}
+	}
+	return AddBotResponse{Game: *g.ViewFor(""), Bots: added}, nil
+}
+
+type RemoveBotRequest struct {
+	GameCode string `json:"gameCode"`
+	PlayerID string `json:"playerID"` // must be the leader of the game
+	BotID    string `json:"botID"`
+}
+
+// RemoveBot implements /api/v1/bots/remove
+func RemoveBot(app logic.Application, req RemoveBotRequest) (interface{}, error) {
+	g, err := game.GetGame(req.GameCode, app.GetMongoDB())
+	if err != nil {
+		return nil, err
+	}
+	if err = g.CheckLeader(req.PlayerID); err != nil {
+		return nil, err
+	}
+	isBot := false
+	for _, p := range g.Bots() {
+		isBot = isBot || p.ID == req.BotID
+	}
+	if !isBot {
+		return nil, stderror.New(stderror.ErrPlayerNotFound, errors.New("bot not found, id: "+req.BotID))
+	}
+	app.GetBotRunner().Stop(req.GameCode, req.BotID)
+	return viewFor("")(game.LeaveGame(req.GameCode, req.BotID, app.GetMongoDB()))
+}
+
+// ListBotStrategies implements /api/v1/bots/strategies
+func ListBotStrategies(app logic.Application) (interface{}, error) {
+	strategies := make([]string, 0, len(bot.Strategies))
+	for _, name := range bot.Strategies {
+		if _, err := bot.New(name, nil, app.GetHintService(), 0); err == nil {
+			strategies = append(strategies, name)
+		}
+	}
+	return strategies, nil
+}
+
+// startBots lets the bots of a game race in the round that just started
+func startBots(app logic.Application, g *game.Game) {
+	for _, p := range g.Bots() {
+		startBot(app, g, p)
+	}
+}
+
+// startBot lets a bot race in the current round of a game, failures are only logged
+// so a broken bot never keeps the humans from playing
+func startBot(app logic.Application, g *game.Game, p game.Player) {
+	w, err := gameWiki(app, g)
+	if err != nil {
+		logger.Errorf("start bot failed, game code: %v, bot: %v, error: %v", g.Code, p.ID, err)
+		return
+	}
+	strategy, err := bot.New(p.Bot.Strategy, w, app.GetHintService(), app.GetConfig().Bots.SearchLimit)
+	if err != nil {
+		logger.Errorf("start bot failed, game code: %v, bot: %v, error: %v", g.Code, p.ID, err)
+		return
+	}
+	app.GetBotRunner().Start(g, p.ID, strategy, p.Bot.Delay, w, botGames{app: app})
+}
+
+// botGames lets bots read games and move through the same code path as human players
+type botGames struct {
+	app logic.Application
+}
+
+func (b botGames) Get(gameCode string) (*game.Game, error) {
+	return game.GetGame(gameCode, b.app.GetMongoDB())
+}
+
+func (b botGames) Move(gameCode, playerID, article string) (*game.Game, error) {
+	return addPath(b.app, AddPathRequest{GameCode: gameCode, PlayerID: playerID, ArticleName: article})
+}
@@ -0,0 +1,98 @@
+package apiv1
+
+import (
+	"time"
+	"wikirace/pkg/daily"
+	"wikirace/pkg/game"
+	"wikirace/pkg/logic"
+)
+
+// dailyPicker creates the challenge picker from the configured seed and pool
+func dailyPicker(app logic.Application) *daily.Picker {
+	config := app.GetConfig()
+	return daily.NewPicker(config.Daily.Seed, config.Daily.Pool)
+}
+
+// GetDailyChallenge implements /api/v1/daily/challenge
+func GetDailyChallenge(app logic.Application, date string) (interface{}, error) {
+	day, err := daily.ParseDate(date, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	return dailyPicker(app).For(day), nil
+}
+
+type StartDailyRequest struct {
+	PlayerID     string `json:"playerID"`
+	AccountID    string `json:"accountID"`    // optional, links the attempt to a persistent account
+	AccountToken string `json:"accountToken"` // required with accountID, proves the player owns the account
+	PlayerName   string `json:"playerName"`
+}
+
+// StartDaily implements /api/v1/daily/start
+func StartDaily(app logic.Application, req StartDailyRequest) (interface{}, error) {
+	name, err := resolvePlayerName(app, req.AccountID, req.AccountToken, req.PlayerName)
+	if err != nil {
+		return nil, err
+	}
+	player := game.Player{ID: req.PlayerID, AccountID: req.AccountID}
+	challenge := dailyPicker(app).For(time.Now())
+	return daily.Start(app.GetDailyStore(), challenge, player.Identity(), req.AccountID, name)
+}
+
+type DailyAddPathRequest struct {
+	PlayerID     string `json:"playerID"`
+	AccountID    string `json:"accountID"`
+	AccountToken string `json:"accountToken"` // required with accountID
+	ArticleName  string `json:"articleName"`
+	Date         string `json:"date"` // date of the attempt as returned by start, empty selects the current attempt
+}
+
+// DailyAddPath implements /api/v1/daily/addpath
+func DailyAddPath(app logic.Application, req DailyAddPathRequest) (interface{}, error) {
+	if req.AccountID != "" {
+		if _, err := ownedAccount(app, req.AccountID, req.AccountToken); err != nil {
+			return nil, err
+		}
+	}
+	player := game.Player{ID: req.PlayerID, AccountID: req.AccountID}
+	return daily.AddPath(app.GetDailyStore(), req.Date, player.Identity(), canonicalTitle(app.GetWikis().Default(), req.ArticleName), time.Now())
+}
+
+type DailyLeaderboardRequest struct {
+	Date   string `form:"date"`
+	Offset int    `form:"offset"`
+	Limit  int    `form:"limit"`
+}
+
+type DailyLeaderboardResponse struct {
+	Challenge daily.Challenge `json:"challenge"`
+	Attempts  []daily.Attempt `json:"attempts"` // fastest first
+}
+
+// GetDailyLeaderboard implements /api/v1/daily/leaderboard
+func GetDailyLeaderboard(app logic.Application, req DailyLeaderboardRequest) (interface{}, error) {
+	day, err := daily.ParseDate(req.Date, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	challenge := dailyPicker(app).For(day)
+	attempts, err := app.GetDailyStore().Leaderboard(challenge.Date, req.Offset, req.Limit)
+	if err != nil {
+		return nil, err
+	}
+	// the ID of an anonymous player lets anyone play their attempt, so only accounts are shown
+	for i := range attempts {
+		attempts[i].PlayerID = ""
+	}
+	return DailyLeaderboardResponse{Challenge: challenge, Attempts: attempts}, nil
+}
+
+// GetDailyStreak implements /api/v1/daily/streak
+func GetDailyStreak(app logic.Application, playerID string) (interface{}, error) {
+	dates, err := app.GetDailyStore().FinishedDates(playerID)
+	if err != nil {
+		return nil, err
+	}
+	return daily.ComputeStreak(dates, time.Now()), nil
+}
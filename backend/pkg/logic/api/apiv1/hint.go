@@ -0,0 +1,149 @@
+package apiv1
+
+import (
+	"context"
+	"errors"
+	"time"
+	"wikirace/pkg/game"
+	"wikirace/pkg/hint"
+	"wikirace/pkg/logic"
+	"wikirace/pkg/stderror"
+)
+
+// hintTimeout bounds how long a provider may take to answer
+const hintTimeout = 20 * time.Second
+
+type HintsRequest struct {
+	Provider       string   `json:"provider"`       // empty uses the server default
+	GameCode       string   `json:"gameCode"`       // takes the target and current article from the game, required unless hints.allowWithoutGame is set
+	PlayerID       string   `json:"playerID"`       // required with gameCode
+	CurrentArticle string   `json:"currentArticle"` // ignored with gameCode
+	TargetArticle  string   `json:"targetArticle"`  // ignored with gameCode
+	Language       string   `json:"language"`       // Wikipedia the articles belong to, empty uses the server default, ignored with gameCode
+	Links          []string `json:"links"`          // links on the current article, other titles are ignored
+	Limit          int      `json:"limit"`
+}
+
+type HintsResponse struct {
+	Provider    string            `json:"provider"`
+	Suggestions []hint.Suggestion `json:"suggestions"` // best first
+}
+
+// GetHints implements /api/v1/hints
+func GetHints(app logic.Application, req HintsRequest) (interface{}, error) {
+	service := app.GetHintService()
+	provider, err := service.Provider(req.Provider)
+	if err != nil {
+		return nil, err
+	}
+	hintReq := hint.Request{
+		CurrentArticle: req.CurrentArticle,
+		TargetArticle:  req.TargetArticle,
+		Language:       req.Language,
+		Links:          req.Links,
+		Limit:          req.Limit,
+	}
+	if req.GameCode == "" {
+		// hints without a game are not charged, so players could use them to bypass their budget
+		if !app.GetConfig().Hints.AllowWithoutGame {
+			return nil, stderror.New(stderror.ErrBadRequest, errors.New("gameCode and playerID are required"))
+		}
+		w, err := languageWiki(app, req.Language)
+		if err != nil {
+			return nil, err
+		}
+		hintReq.Language = w.Language()
+	} else {
+		if err := positionInGame(app, req.GameCode, req.PlayerID, &hintReq); err != nil {
+			return nil, err
+		}
+	}
+	if hintReq.TargetArticle == "" {
+		return nil, stderror.New(stderror.ErrBadRequest, errors.New("targetArticle is required"))
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), hintTimeout)
+	defer cancel()
+	if err := verifyArticles(ctx, app, &hintReq); err != nil {
+		return nil, err
+	}
+	suggestions, err := service.Suggest(ctx, provider.Name(), hintReq)
+	if err != nil {
+		return nil, err
+	}
+
+	// only charge the hint once the player actually got suggestions
+	if req.GameCode != "" && len(suggestions) > 0 {
+		if _, err := game.UseHint(req.GameCode, req.PlayerID, app.GetMongoDB()); err != nil {
+			return nil, err
+		}
+	}
+	return HintsResponse{Provider: provider.Name(), Suggestions: suggestions}, nil
+}
+
+// positionInGame fills the target and current article and the language of a hint request from a player's game,
+// so players cannot ask for hints towards another target, and checks the player's hint budget
+func positionInGame(app logic.Application, gameCode, playerID string, req *hint.Request) error {
+	g, err := game.GetGame(gameCode, app.GetMongoDB())
+	if err != nil {
+		return err
+	}
+	if err = g.CheckHint(playerID); err != nil {
+		return err
+	}
+	for _, p := range g.Players {
+		if p.ID != playerID {
+			continue
+		}
+		w, err := gameWiki(app, g)
+		if err != nil {
+			return err
+		}
+		req.Language = w.Language()
+		req.TargetArticle = g.TargetArticle
+		req.CurrentArticle = g.StartArticle
+		if len(p.Paths) > 0 {
+			req.CurrentArticle = p.Paths[len(p.Paths)-1]
+		}
+		return nil
+	}
+	return stderror.New(stderror.ErrPlayerNotFound, errors.New("player not found, id: "+playerID))
+}
+
+// verifyArticles replaces the target and the links of a hint request by the titles of existing articles:
+// the links are limited to the ones of the current article, so providers never rank, embed or
+// cache made up titles
+func verifyArticles(ctx context.Context, app logic.Application, req *hint.Request) error {
+	w, err := languageWiki(app, req.Language)
+	if err != nil {
+		return err
+	}
+	target, err := w.Article(ctx, req.TargetArticle)
+	if err != nil {
+		return err
+	}
+	req.TargetArticle = target.Title
+	current, err := w.Article(ctx, req.CurrentArticle)
+	if err != nil {
+		return err
+	}
+	onArticle := make(map[string]string, len(current.Links))
+	for _, link := range current.Links {
+		onArticle[w.Titles().Normalize(link)] = link
+	}
+	links := make([]string, 0, len(req.Links))
+	seen := make(map[string]bool, len(req.Links))
+	for _, link := range req.Links {
+		if title, ok := onArticle[w.Titles().Normalize(link)]; ok && !seen[title] {
+			seen[title] = true
+			links = append(links, title)
+		}
+	}
+	req.Links = links
+	return nil
+}
+
+// ListHintProviders implements /api/v1/hints/providers
+func ListHintProviders(app logic.Application) (interface{}, error) {
+	return app.GetHintService().Providers(), nil
+}
@@ -0,0 +1,85 @@
+package apiv1
+
+import (
+	"time"
+	"wikirace/pkg/logic"
+	"wikirace/pkg/match"
+)
+
+type LeaderboardRequest struct {
+	Metric        string `form:"metric"`
+	Window        string `form:"window"` // "day", "week", "month", "year" or "all"
+	StartArticle  string `form:"startArticle"`
+	TargetArticle string `form:"targetArticle"`
+	MinPlayed     int    `form:"minPlayed"`
+	PlayerID      string `form:"playerID"` // the caller's own identity, player only
+	Offset        int    `form:"offset"`
+	Limit         int    `form:"limit"`
+}
+
+type LeaderboardResponse struct {
+	Metric  match.Metric             `json:"metric"`
+	Entries []match.LeaderboardEntry `json:"entries"`
+}
+
+type PlayerLeaderboardResponse struct {
+	Metric match.Metric            `json:"metric"`
+	Entry  *match.LeaderboardEntry `json:"entry"` // nil if the player is not ranked
+}
+
+// buildLeaderboardQuery validates a leaderboard request and converts it to a store query
+func buildLeaderboardQuery(req LeaderboardRequest) (match.LeaderboardQuery, error) {
+	metric, err := match.ParseMetric(req.Metric)
+	if err != nil {
+		return match.LeaderboardQuery{}, err
+	}
+	since, err := match.WindowStart(req.Window, time.Now())
+	if err != nil {
+		return match.LeaderboardQuery{}, err
+	}
+	return match.LeaderboardQuery{
+		Metric:        metric,
+		Since:         since,
+		StartArticle:  req.StartArticle,
+		TargetArticle: req.TargetArticle,
+		MinPlayed:     req.MinPlayed,
+		PlayerID:      req.PlayerID,
+		Offset:        req.Offset,
+		Limit:         req.Limit,
+	}, nil
+}
+
+// GetLeaderboard implements /api/v1/leaderboards
+func GetLeaderboard(app logic.Application, req LeaderboardRequest) (interface{}, error) {
+	req.PlayerID = ""
+	q, err := buildLeaderboardQuery(req)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := app.GetMatchStore().Leaderboard(q)
+	if err != nil {
+		return nil, err
+	}
+	for i := range entries {
+		entries[i] = entries[i].Public()
+	}
+	return LeaderboardResponse{Metric: q.Metric, Entries: entries}, nil
+}
+
+// GetPlayerLeaderboard implements /api/v1/leaderboards/player
+func GetPlayerLeaderboard(app logic.Application, req LeaderboardRequest) (interface{}, error) {
+	q, err := buildLeaderboardQuery(req)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := app.GetMatchStore().Leaderboard(q)
+	if err != nil {
+		return nil, err
+	}
+	resp := PlayerLeaderboardResponse{Metric: q.Metric}
+	if len(entries) > 0 {
+		entry := entries[0].Public()
+		resp.Entry = &entry
+	}
+	return resp, nil
+}
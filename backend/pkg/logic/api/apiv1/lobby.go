@@ -0,0 +1,104 @@
+package apiv1
+
+import (
+	"time"
+	"wikirace/pkg/game"
+	"wikirace/pkg/logger"
+	"wikirace/pkg/logic"
+	"wikirace/pkg/matchmaking"
+)
+
+type ListLobbiesRequest struct {
+	Offset int `form:"offset"`
+	Limit  int `form:"limit"`
+}
+
+type ListLobbiesResponse struct {
+	Lobbies []game.Lobby `json:"lobbies"`
+}
+
+// ListLobbies implements /api/v1/games/list
+func ListLobbies(app logic.Application, req ListLobbiesRequest) (interface{}, error) {
+	lobbies, err := game.ListLobbies(req.Offset, req.Limit, app.GetMongoDB())
+	if err != nil {
+		return nil, err
+	}
+	return ListLobbiesResponse{Lobbies: lobbies}, nil
+}
+
+// createMatchedGame creates a private game for a group formed by the matchmaking queue.
+// If a player cannot be added the game is deleted again, as the group goes back to the queue.
+func createMatchedGame(app logic.Application) matchmaking.CreateFunc {
+	return func(group []matchmaking.Ticket) (string, error) {
+		leader := group[0]
+		settings, err := gameSettings(app, false, 0, "")
+		if err != nil {
+			return "", err
+		}
+		// the group has to fit into the game even if the server limit is lower than the group size
+		if settings.MaxPlayers > 0 {
+			settings.MaxPlayers = max(settings.MaxPlayers, len(group))
+		}
+		g, err := game.CreateGame(leader.Name, leader.PlayerID, leader.AccountID, leader.Client, settings, app.GetMongoDB())
+		if err != nil {
+			return "", err
+		}
+		for _, t := range group[1:] {
+			if _, err = game.JoinGame(g.Code, t.PlayerID, t.AccountID, t.Name, "", t.Client, app.GetMongoDB()); err != nil {
+				if deleteErr := game.DeleteGame(g.Code, app.GetMongoDB()); deleteErr != nil {
+					logger.Errorf("delete unfinished matched game failed, code: %v, error: %v", g.Code, deleteErr)
+				}
+				return "", err
+			}
+		}
+		return g.Code, nil
+	}
+}
+
+type JoinMatchmakingRequest struct {
+	PlayerID     string `json:"playerID"`
+	AccountID    string `json:"accountID"`    // optional, links the player to a persistent account
+	AccountToken string `json:"accountToken"` // required with accountID, proves the player owns the account
+	PlayerName   string `json:"playerName"`
+	ClientIP     string `json:"-"` // recorded on the player of the matched game, see game.Player.Client
+}
+
+// JoinMatchmaking implements /api/v1/matchmaking/join
+func JoinMatchmaking(app logic.Application, req JoinMatchmakingRequest) (interface{}, error) {
+	name, err := resolvePlayerName(app, req.AccountID, req.AccountToken, req.PlayerName)
+	if err != nil {
+		return nil, err
+	}
+	queue := app.GetMatchmakingQueue()
+	queue.Join(matchmaking.Ticket{
+		PlayerID:  req.PlayerID,
+		AccountID: req.AccountID,
+		Name:      name,
+		Client:    req.ClientIP,
+		QueuedAt:  time.Now(),
+	})
+	if err = queue.Poll(time.Now(), createMatchedGame(app)); err != nil {
+		return nil, err
+	}
+	return queue.Status(req.PlayerID, time.Now()), nil
+}
+
+// GetMatchmakingStatus implements /api/v1/matchmaking/status
+func GetMatchmakingStatus(app logic.Application, playerID string) (interface{}, error) {
+	queue := app.GetMatchmakingQueue()
+	if err := queue.Poll(time.Now(), createMatchedGame(app)); err != nil {
+		return nil, err
+	}
+	return queue.Status(playerID, time.Now()), nil
+}
+
+type LeaveMatchmakingRequest struct {
+	PlayerID string `json:"playerID"`
+}
+
+// LeaveMatchmaking implements /api/v1/matchmaking/leave
+func LeaveMatchmaking(app logic.Application, req LeaveMatchmakingRequest) (interface{}, error) {
+	queue := app.GetMatchmakingQueue()
+	queue.Leave(req.PlayerID)
+	return queue.Status(req.PlayerID, time.Now()), nil
+}
@@ -0,0 +1,160 @@
+package apiv1
+
+import (
+	"context"
+	"errors"
+	"time"
+	"wikirace/pkg/anticheat"
+	"wikirace/pkg/game"
+	"wikirace/pkg/logger"
+	"wikirace/pkg/logic"
+	"wikirace/pkg/match"
+	"wikirace/pkg/rating"
+	"wikirace/pkg/stderror"
+)
+
+// analysisTimeout bounds the articles read by the anti-cheat analysis of a finished round
+const analysisTimeout = 10 * time.Second
+
+// archiveFinishedGame stores a finished round in the match history.
+// Failures are only logged so that archiving never breaks the game itself.
+func archiveFinishedGame(app logic.Application, g *game.Game) {
+	if g == nil || g.State != "finished" {
+		return
+	}
+	m := match.FromGame(g)
+	created, err := app.GetMatchStore().Save(m)
+	if err != nil {
+		logger.Errorf("archive match failed, game code: %v, error: %v", g.Code, err)
+		return
+	}
+	// analyze and rate the round only once, when it is first archived
+	if !created {
+		return
+	}
+	if app.GetConfig().AntiCheat.Enabled {
+		// the analysis reads articles, so it must not hold up the request; ratings wait for its verdict
+		go reviewMatch(app, m)
+		return
+	}
+	rateMatch(app, m)
+}
+
+// reviewMatch runs the anti-cheat analysis of an archived match, stores its flags, takes a voided win
+// away in the game as well and rates the match
+func reviewMatch(app logic.Application, m *match.Match) {
+	winnerID := m.WinnerID
+	voided := analyzeMatch(app, m)
+	if err := app.GetMatchStore().Update(m); err != nil {
+		logger.Errorf("store match analysis failed, match id: %v, error: %v", m.ID, err)
+	}
+	if voided {
+		if err := game.VoidWin(m.GameCode, m.StartTime, winnerID, app.GetMongoDB()); err != nil {
+			logger.Errorf("void win in game failed, match id: %v, error: %v", m.ID, err)
+		}
+	}
+	rateMatch(app, m)
+}
+
+// rateMatch updates the ratings of the participants of a match
+func rateMatch(app logic.Application, m *match.Match) {
+	if _, err := rating.Apply(app.GetRatingStore(), m); err != nil {
+		logger.Errorf("update ratings failed, match id: %v, error: %v", m.ID, err)
+	}
+}
+
+// analyzeMatch flags the suspicious moves of a match and voids the win if the configuration says so,
+// it reports whether the win was voided
+func analyzeMatch(app logic.Application, m *match.Match) bool {
+	config := app.GetConfig().AntiCheat
+	var links anticheat.Links
+	if w, err := app.GetWikis().Service(m.Language); err == nil {
+		links = w
+	} else {
+		logger.Warnf("match played on an unsupported wiki, links not checked, match id: %v, language: %v", m.ID, m.Language)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), analysisTimeout)
+	defer cancel()
+	m.Flags = anticheat.Analyze(ctx, m, links, anticheat.Rules{
+		MinMove:    time.Duration(config.MinMoveMs) * time.Millisecond,
+		CheckLinks: config.CheckLinks,
+	})
+	if !anticheat.Void(m, config.VoidOn) {
+		return false
+	}
+	logger.Infof("win voided by the anti-cheat analysis, match id: %v", m.ID)
+	return true
+}
+
+type ListMatchesRequest struct {
+	PlayerID string `form:"playerID"` // the caller's own identity
+	Offset   int    `form:"offset"`
+	Limit    int    `form:"limit"`
+}
+
+type ListMatchesResponse struct {
+	Matches []match.Match `json:"matches"`
+}
+
+// ListMatches implements /api/v1/matches/list
+func ListMatches(app logic.Application, req ListMatchesRequest) (interface{}, error) {
+	matches, err := app.GetMatchStore().ListByPlayer(req.PlayerID, req.Offset, req.Limit)
+	if err != nil {
+		return nil, err
+	}
+	for i := range matches {
+		matches[i] = matches[i].Public()
+	}
+	return ListMatchesResponse{Matches: matches}, nil
+}
+
+// GetMatch implements /api/v1/matches/info
+func GetMatch(app logic.Application, matchID string) (interface{}, error) {
+	m, err := app.GetMatchStore().Get(matchID)
+	if err != nil {
+		return nil, err
+	}
+	public := m.Public()
+	return &public, nil
+}
+
+type ReviewMatchRequest struct {
+	GameCode string `form:"gameCode"`
+	PlayerID string `form:"playerID"` // must be the leader of the game
+	MatchID  string `form:"matchID"`  // a past round of the game, defaults to the current round
+}
+
+type ReviewMatchResponse struct {
+	Match *match.Match `json:"match"`
+	Flags []match.Flag `json:"flags"`
+}
+
+// ReviewMatch implements /api/v1/matches/review
+func ReviewMatch(app logic.Application, req ReviewMatchRequest) (interface{}, error) {
+	g, err := game.GetGame(req.GameCode, app.GetMongoDB())
+	if err != nil {
+		return nil, err
+	}
+	if err = g.CheckLeader(req.PlayerID); err != nil {
+		return nil, err
+	}
+	matchID := req.MatchID
+	if matchID == "" {
+		if g.State != "finished" {
+			return nil, stderror.New(stderror.ErrGameNotFinished, errors.New("game not finished, code: "+g.Code))
+		}
+		matchID = match.MatchID(g)
+	}
+	m, err := app.GetMatchStore().Get(matchID)
+	if err != nil {
+		return nil, err
+	}
+	if m.GameCode != g.Code {
+		return nil, stderror.New(stderror.ErrMatchNotFound, errors.New("match not played in game, id: "+matchID))
+	}
+	flags := m.Flags
+	if flags == nil {
+		flags = []match.Flag{}
+	}
+	return ReviewMatchResponse{Match: m, Flags: flags}, nil
+}
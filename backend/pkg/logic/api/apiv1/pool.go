@@ -0,0 +1,233 @@
+package apiv1
+
+import (
+	"context"
+	"errors"
+	"time"
+	"wikirace/pkg/logic"
+	"wikirace/pkg/pool"
+	"wikirace/pkg/stderror"
+	"wikirace/pkg/wiki"
+)
+
+// importTimeout bounds how long listing the articles of a category may take
+const importTimeout = 60 * time.Second
+
+type CreatePoolRequest struct {
+	Name         string   `json:"name"`
+	Description  string   `json:"description"`
+	Language     string   `json:"language"` // empty uses the server default
+	Articles     []string `json:"articles"`
+	AccountID    string   `json:"accountID"`    // the account that will own the pool
+	AccountToken string   `json:"accountToken"` // proves the caller owns the account
+}
+
+// CreatePool implements /api/v1/pools/create
+func CreatePool(app logic.Application, req CreatePoolRequest) (interface{}, error) {
+	if _, err := ownedAccount(app, req.AccountID, req.AccountToken); err != nil {
+		return nil, err
+	}
+	w, err := languageWiki(app, req.Language)
+	if err != nil {
+		return nil, err
+	}
+	p, err := pool.New(req.Name, req.Description, w.Language(), req.AccountID, req.Articles)
+	if err != nil {
+		return nil, err
+	}
+	if err = app.GetPoolStore().Create(p); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// GetPool implements /api/v1/pools/info
+func GetPool(app logic.Application, poolID string) (interface{}, error) {
+	return app.GetPoolStore().Get(poolID)
+}
+
+type ListPoolsRequest struct {
+	Language string `form:"language"` // empty lists the pools of every language
+	Offset   int    `form:"offset"`
+	Limit    int    `form:"limit"`
+}
+
+type ListPoolsResponse struct {
+	Pools []pool.Summary `json:"pools"`
+}
+
+// ListPools implements /api/v1/pools/list
+func ListPools(app logic.Application, req ListPoolsRequest) (interface{}, error) {
+	pools, err := app.GetPoolStore().List(req.Language, req.Offset, req.Limit)
+	if err != nil {
+		return nil, err
+	}
+	summaries := make([]pool.Summary, 0, len(pools))
+	for _, p := range pools {
+		summaries = append(summaries, p.Summary())
+	}
+	return ListPoolsResponse{Pools: summaries}, nil
+}
+
+type UpdatePoolRequest struct {
+	PoolID       string   `json:"poolID"`
+	Name         string   `json:"name"`
+	Description  string   `json:"description"`
+	Articles     []string `json:"articles"`     // replaces the articles of the pool
+	AccountID    string   `json:"accountID"`    // must own the pool
+	AccountToken string   `json:"accountToken"` // proves the caller owns the account
+}
+
+// UpdatePool implements /api/v1/pools/update
+func UpdatePool(app logic.Application, req UpdatePoolRequest) (interface{}, error) {
+	p, err := ownedPool(app, req.PoolID, req.AccountID, req.AccountToken)
+	if err != nil {
+		return nil, err
+	}
+	if err = p.Set(req.Name, req.Description, req.Articles); err != nil {
+		return nil, err
+	}
+	if err = app.GetPoolStore().Update(p); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+type DeletePoolRequest struct {
+	PoolID       string `json:"poolID"`
+	AccountID    string `json:"accountID"`    // must own the pool
+	AccountToken string `json:"accountToken"` // proves the caller owns the account
+}
+
+// DeletePool implements /api/v1/pools/delete
+func DeletePool(app logic.Application, req DeletePoolRequest) (interface{}, error) {
+	if _, err := ownedPool(app, req.PoolID, req.AccountID, req.AccountToken); err != nil {
+		return nil, err
+	}
+	return nil, app.GetPoolStore().Delete(req.PoolID)
+}
+
+type ImportPoolRequest struct {
+	PoolID       string `json:"poolID"`       // add the articles to an existing pool, or create a new one if empty
+	Name         string `json:"name"`         // new pools only
+	Description  string `json:"description"`  // new pools only
+	Language     string `json:"language"`     // new pools only, empty uses the server default
+	Format       string `json:"format"`       // "text" (default), "csv" or "category"
+	Content      string `json:"content"`      // the text or CSV list
+	Category     string `json:"category"`     // the category to import with the category format, e.g. "Physics"
+	AccountID    string `json:"accountID"`    // must own the existing pool, or owns the new one
+	AccountToken string `json:"accountToken"` // proves the caller owns the account
+}
+
+// ImportPool implements /api/v1/pools/import
+func ImportPool(app logic.Application, req ImportPoolRequest) (interface{}, error) {
+	var p *pool.Pool
+	language := req.Language
+	if req.PoolID != "" {
+		var err error
+		if p, err = ownedPool(app, req.PoolID, req.AccountID, req.AccountToken); err != nil {
+			return nil, err
+		}
+		language = p.Language
+	} else if _, err := ownedAccount(app, req.AccountID, req.AccountToken); err != nil {
+		return nil, err
+	}
+	w, err := languageWiki(app, language)
+	if err != nil {
+		return nil, err
+	}
+	imported, err := importArticles(w, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if p == nil {
+		if p, err = pool.New(req.Name, req.Description, w.Language(), req.AccountID, imported); err != nil {
+			return nil, err
+		}
+		err = app.GetPoolStore().Create(p)
+	} else {
+		// Set drops the titles the pool already has
+		if err = p.Set(p.Name, p.Description, append(p.Articles, imported...)); err != nil {
+			return nil, err
+		}
+		err = app.GetPoolStore().Update(p)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// ownedPool returns a pool after checking that the caller owns the account that created it
+func ownedPool(app logic.Application, poolID, accountID, accountToken string) (*pool.Pool, error) {
+	p, err := app.GetPoolStore().Get(poolID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = ownedAccount(app, accountID, accountToken); err != nil {
+		return nil, err
+	}
+	if err = p.CheckOwner(accountID); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// importArticles reads the titles of an import request from its list or its category
+func importArticles(w *wiki.Service, req ImportPoolRequest) ([]string, error) {
+	if req.Format != pool.FormatCategory {
+		return pool.ParseList(req.Format, req.Content)
+	}
+	if req.Category == "" {
+		return nil, stderror.New(stderror.ErrBadRequest, errors.New("category is required"))
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), importTimeout)
+	defer cancel()
+	return w.CategoryMembers(ctx, req.Category, pool.MaxArticles)
+}
+
+// poolArticles fills the empty start and target articles of a game with random articles of a pool.
+// Given articles must belong to the pool, drawn pairs are validated and drawn again if they cannot be played.
+// Articles are passed as the client sent them and returned canonicalized, so pool entries that are
+// redirects match as well.
+func poolArticles(app logic.Application, w *wiki.Service, poolID, start, target string) (string, string, error) {
+	p, err := app.GetPoolStore().Get(poolID)
+	if err != nil {
+		return "", "", err
+	}
+	if p.Language != w.Language() {
+		return "", "", stderror.NewValidation(stderror.FieldErrors{{
+			Field: "poolID", Reason: "language", Message: "the pool " + p.Name + " is not in the language of the game",
+		}})
+	}
+	canonicalStart, canonicalTarget := canonicalTitle(w, start), canonicalTitle(w, target)
+	fields := stderror.FieldErrors{}
+	if start != "" && !p.Contains(start) && !p.Contains(canonicalStart) {
+		fields = append(fields, stderror.FieldError{Field: "startArticle", Reason: "pool", Message: canonicalStart + " is not in the pool " + p.Name})
+	}
+	if target != "" && !p.Contains(target) && !p.Contains(canonicalTarget) {
+		fields = append(fields, stderror.FieldError{Field: "targetArticle", Reason: "pool", Message: canonicalTarget + " is not in the pool " + p.Name})
+	}
+	if len(fields) > 0 {
+		return "", "", stderror.NewValidation(fields)
+	}
+	if start != "" && target != "" {
+		return canonicalStart, canonicalTarget, validateArticles(w, canonicalStart, canonicalTarget, false)
+	}
+	for i := 0; i < randomPairAttempts; i++ {
+		pickedStart, pickedTarget := start, target
+		if pickedStart == "" {
+			pickedStart, _ = p.Random(pickedTarget)
+		}
+		if pickedTarget == "" {
+			pickedTarget, _ = p.Random(pickedStart)
+		}
+		pickedStart, pickedTarget = canonicalTitle(w, pickedStart), canonicalTitle(w, pickedTarget)
+		err = validateArticles(w, pickedStart, pickedTarget, true)
+		if stderror.Fields(err) == nil {
+			return pickedStart, pickedTarget, err
+		}
+	}
+	return "", "", err
+}
@@ -0,0 +1,53 @@
+package apiv1
+
+import (
+	"math"
+	"wikirace/pkg/game"
+	"wikirace/pkg/logger"
+	"wikirace/pkg/logic"
+	"wikirace/pkg/rating"
+)
+
+// attachRatings fills in the current rating of every player of a game.
+// Failures are only logged since ratings are not needed to play.
+func attachRatings(app logic.Application, g *game.Game) {
+	ids := make([]string, 0, len(g.Players))
+	for _, p := range g.Players {
+		ids = append(ids, p.Identity())
+	}
+	ratings, err := app.GetRatingStore().Get(ids)
+	if err != nil {
+		logger.Errorf("get ratings failed, game code: %v, error: %v", g.Code, err)
+		return
+	}
+	for i, p := range g.Players {
+		g.Players[i].Rating = int(math.Round(ratings[p.Identity()].Rating))
+	}
+}
+
+type RatingProfileRequest struct {
+	PlayerID string `form:"playerID"`
+	Offset   int    `form:"offset"`
+	Limit    int    `form:"limit"`
+}
+
+type RatingProfileResponse struct {
+	Rating  rating.Rating   `json:"rating"`
+	History []rating.Change `json:"history"` // most recent first
+}
+
+// GetRatingProfile implements /api/v1/ratings/profile
+func GetRatingProfile(app logic.Application, req RatingProfileRequest) (interface{}, error) {
+	ratings, err := app.GetRatingStore().Get([]string{req.PlayerID})
+	if err != nil {
+		return nil, err
+	}
+	history, err := app.GetRatingStore().History(req.PlayerID, req.Offset, req.Limit)
+	if err != nil {
+		return nil, err
+	}
+	return RatingProfileResponse{
+		Rating:  ratings[req.PlayerID],
+		History: history,
+	}, nil
+}
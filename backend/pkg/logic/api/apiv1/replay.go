@@ -0,0 +1,49 @@
+package apiv1
+
+import (
+	"errors"
+	"wikirace/pkg/game"
+	"wikirace/pkg/logic"
+	"wikirace/pkg/match"
+	"wikirace/pkg/replay"
+	"wikirace/pkg/stderror"
+)
+
+type ExportReplayRequest struct {
+	GameCode string `form:"gameCode"` // export the finished round of a game
+	PlayerID string `form:"playerID"` // with gameCode, required to export the paths of a protected game
+	MatchID  string `form:"matchID"`  // or export an archived match
+}
+
+// ExportReplay implements /api/v1/replays/export
+func ExportReplay(app logic.Application, req ExportReplayRequest) (interface{}, error) {
+	if req.MatchID != "" {
+		m, err := app.GetMatchStore().Get(req.MatchID)
+		if err != nil {
+			return nil, err
+		}
+		return replay.FromMatch(m), nil
+	}
+	g, err := game.GetGame(req.GameCode, app.GetMongoDB())
+	if err != nil {
+		return nil, err
+	}
+	if g.State != "finished" {
+		return nil, stderror.New(stderror.ErrGameNotFinished, errors.New("game not finished, code: "+g.Code))
+	}
+	// like GetGame, protected games only show the paths to the players and spectators who entered the password
+	if g.Protected && !g.IsMember(req.PlayerID) {
+		g = g.Redacted()
+	}
+	return replay.FromMatch(match.FromGame(g)), nil
+}
+
+// ImportReplay implements /api/v1/replays/import
+func ImportReplay(app logic.Application, req replay.Replay) (interface{}, error) {
+	return replay.Import(app.GetReplayStore(), &req)
+}
+
+// GetReplay implements /api/v1/replays/info
+func GetReplay(app logic.Application, replayID string) (interface{}, error) {
+	return app.GetReplayStore().Get(replayID)
+}
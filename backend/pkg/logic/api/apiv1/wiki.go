@@ -0,0 +1,158 @@
+package apiv1
+
+import (
+	"context"
+	"time"
+	"wikirace/pkg/game"
+	"wikirace/pkg/logic"
+	"wikirace/pkg/stderror"
+	"wikirace/pkg/wiki"
+)
+
+const (
+	// articleTimeout bounds how long fetching an article from the origin may take
+	articleTimeout = 20 * time.Second
+	// randomPairAttempts is how often random articles are drawn before giving up on a playable pair
+	randomPairAttempts = 5
+)
+
+// languageWiki returns the wiki of a language, an empty language selects the server default
+func languageWiki(app logic.Application, language string) (*wiki.Service, error) {
+	return app.GetWikis().Service(language)
+}
+
+// gameWiki returns the wiki a game is played on
+func gameWiki(app logic.Application, g *game.Game) (*wiki.Service, error) {
+	return languageWiki(app, g.Language)
+}
+
+// canonicalTitle normalizes a title and resolves redirects, see wiki.Service.Canonicalize
+func canonicalTitle(w *wiki.Service, title string) string {
+	ctx, cancel := context.WithTimeout(context.Background(), articleTimeout)
+	defer cancel()
+	return w.Canonicalize(ctx, title)
+}
+
+// GetArticle implements /api/v1/wiki/article
+func GetArticle(app logic.Application, language, title string) (interface{}, error) {
+	w, err := languageWiki(app, language)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), articleTimeout)
+	defer cancel()
+	return w.Article(ctx, title)
+}
+
+type RandomArticleResponse struct {
+	Language string `json:"language"`
+	Title    string `json:"title"`
+}
+
+// GetRandomArticle implements /api/v1/wiki/random
+func GetRandomArticle(app logic.Application, language string) (interface{}, error) {
+	w, err := languageWiki(app, language)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), articleTimeout)
+	defer cancel()
+	title, err := w.Random(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return RandomArticleResponse{Language: w.Language(), Title: title}, nil
+}
+
+type LanguagesResponse struct {
+	Default   string   `json:"default"`
+	Languages []string `json:"languages"`
+}
+
+// ListLanguages implements /api/v1/wiki/languages
+func ListLanguages(app logic.Application) (interface{}, error) {
+	wikis := app.GetWikis()
+	return LanguagesResponse{Default: wikis.DefaultLanguage(), Languages: wikis.Languages()}, nil
+}
+
+// randomPair draws random start and target articles until they pass validateArticles
+func randomPair(w *wiki.Service) (string, string, error) {
+	var err error
+	for i := 0; i < randomPairAttempts; i++ {
+		ctx, cancel := context.WithTimeout(context.Background(), articleTimeout)
+		start, startErr := w.Random(ctx)
+		target, targetErr := w.Random(ctx)
+		cancel()
+		if startErr != nil {
+			return "", "", startErr
+		}
+		if targetErr != nil {
+			return "", "", targetErr
+		}
+		if err = validateArticles(w, start, target, true); stderror.Fields(err) == nil {
+			return start, target, err
+		}
+	}
+	return "", "", err
+}
+
+// validateArticles checks the start and target articles of a game. Empty articles are only rejected
+// if required is set, so leaders can pick the articles one at a time in the lobby.
+func validateArticles(w *wiki.Service, start, target string, required bool) error {
+	ctx, cancel := context.WithTimeout(context.Background(), articleTimeout)
+	defer cancel()
+	fields := stderror.FieldErrors{}
+	startPage, err := checkArticle(ctx, w, "startArticle", start, required, &fields)
+	if err != nil {
+		return err
+	}
+	targetPage, err := checkArticle(ctx, w, "targetArticle", target, required, &fields)
+	if err != nil {
+		return err
+	}
+
+	if startPage != nil && targetPage != nil && startPage.Title == targetPage.Title {
+		fields = append(fields, stderror.FieldError{Field: "targetArticle", Reason: "identical", Message: "start and target article are the same"})
+	}
+	if targetPage != nil && !targetPage.Linked {
+		fields = append(fields, stderror.FieldError{Field: "targetArticle", Reason: "unreachable", Message: "no article links to " + targetPage.Title})
+	}
+	if startPage != nil {
+		article, err := w.Article(ctx, startPage.Title)
+		if err != nil {
+			return err
+		}
+		if len(article.Links) == 0 {
+			fields = append(fields, stderror.FieldError{Field: "startArticle", Reason: "dead_end", Message: startPage.Title + " links to no other article"})
+		}
+	}
+	if len(fields) > 0 {
+		return stderror.NewValidation(fields)
+	}
+	return nil
+}
+
+// checkArticle validates a single article and returns its page, or nil if it is empty or invalid
+func checkArticle(ctx context.Context, w *wiki.Service, field, title string, required bool, fields *stderror.FieldErrors) (*wiki.Page, error) {
+	if title == "" {
+		if required {
+			*fields = append(*fields, stderror.FieldError{Field: field, Reason: "required", Message: "an article is required"})
+		}
+		return nil, nil
+	}
+	page, err := w.Lookup(ctx, title)
+	if err != nil {
+		return nil, stderror.New(stderror.ErrAPI, err)
+	}
+	switch {
+	case !page.Exists:
+		*fields = append(*fields, stderror.FieldError{Field: field, Reason: "not_found", Message: title + " does not exist"})
+	case !page.MainNamespace:
+		*fields = append(*fields, stderror.FieldError{Field: field, Reason: "namespace", Message: title + " is not an article"})
+	case page.Disambiguation:
+		*fields = append(*fields, stderror.FieldError{Field: field, Reason: "disambiguation", Message: title + " is a disambiguation page"})
+	default:
+		return page, nil
+	}
+	return nil, nil
+}
@@ -2,10 +2,30 @@ package logic
 
 import (
 	"go.mongodb.org/mongo-driver/mongo"
+	"wikirace/pkg/account"
+	"wikirace/pkg/bot"
 	"wikirace/pkg/cfg"
+	"wikirace/pkg/daily"
+	"wikirace/pkg/hint"
+	"wikirace/pkg/match"
+	"wikirace/pkg/matchmaking"
+	"wikirace/pkg/pool"
+	"wikirace/pkg/rating"
+	"wikirace/pkg/replay"
+	"wikirace/pkg/wiki"
 )
 
 type Application interface {
 	GetConfig() cfg.Config
 	GetMongoDB() *mongo.Client
+	GetMatchStore() match.Store
+	GetRatingStore() rating.Store
+	GetAccountStore() account.Store
+	GetReplayStore() replay.Store
+	GetDailyStore() daily.Store
+	GetPoolStore() pool.Store
+	GetMatchmakingQueue() *matchmaking.Queue
+	GetHintService() *hint.Service
+	GetBotRunner() *bot.Runner
+	GetWikis() *wiki.Wikis
 }
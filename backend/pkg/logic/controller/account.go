@@ -0,0 +1,57 @@
+package controller
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"wikirace/pkg/logic/api/apiv1"
+	"wikirace/pkg/stderror"
+)
+
+// CreateAccount implements /api/v1/accounts/create
+func (a *APIV1) CreateAccount(ctx *gin.Context) {
+	var req apiv1.CreateAccountRequest
+	if err := ctx.Bind(&req); err != nil {
+		SendResponse(ctx, nil, stderror.New(stderror.ErrBind, err))
+		return
+	}
+	data, err := apiv1.CreateAccount(a.app, req)
+	if err != nil {
+		SendResponse(ctx, nil, err)
+		return
+	}
+	SendResponse(ctx, data, nil)
+}
+
+// GetAccount implements /api/v1/accounts/info
+func (a *APIV1) GetAccount(ctx *gin.Context) {
+	accountID := ctx.Query("accountID")
+	if accountID == "" {
+		SendResponse(ctx, nil, stderror.New(stderror.ErrBadRequest, errors.New("accountID is required")))
+		return
+	}
+	data, err := apiv1.GetAccount(a.app, accountID)
+	if err != nil {
+		SendResponse(ctx, nil, err)
+		return
+	}
+	SendResponse(ctx, data, nil)
+}
+
+// UpdateAccount implements /api/v1/accounts/update
+func (a *APIV1) UpdateAccount(ctx *gin.Context) {
+	var req apiv1.UpdateAccountRequest
+	if err := ctx.Bind(&req); err != nil {
+		SendResponse(ctx, nil, stderror.New(stderror.ErrBind, err))
+		return
+	}
+	if req.AccountID == "" {
+		SendResponse(ctx, nil, stderror.New(stderror.ErrBadRequest, errors.New("accountID is required")))
+		return
+	}
+	data, err := apiv1.UpdateAccount(a.app, req)
+	if err != nil {
+		SendResponse(ctx, nil, err)
+		return
+	}
+	SendResponse(ctx, data, nil)
+}
@@ -35,9 +35,11 @@ func (a *APIV1) CreateGame(ctx *gin.Context) {
 		SendResponse(ctx, nil, stderror.New(stderror.ErrBind, err))
 		return
 	}
+	req.ClientIP = ctx.ClientIP()
 	data, err := apiv1.CreateGame(a.app, req)
 	if err != nil {
-		SendResponse(ctx, nil, stderror.New(stderror.ErrInternal, err))
+		SendResponse(ctx, nil, err)
+		return
 	}
 	SendResponse(ctx, data, nil)
 }
@@ -49,6 +51,7 @@ func (a *APIV1) JoinGame(ctx *gin.Context) {
 		SendResponse(ctx, nil, stderror.New(stderror.ErrBind, err))
 		return
 	}
+	req.ClientIP = ctx.ClientIP()
 	data, err := apiv1.JoinGame(a.app, req)
 	if err != nil {
 		SendResponse(ctx, nil, err)
@@ -64,7 +67,7 @@ func (a *APIV1) GetGame(ctx *gin.Context) {
 		SendResponse(ctx, nil, stderror.New(stderror.ErrBadRequest, errors.New("gameCode is required")))
 		return
 	}
-	data, err := apiv1.GetGame(a.app, gameCode)
+	data, err := apiv1.GetGame(a.app, gameCode, ctx.Query("playerID"))
 	if err != nil {
 		SendResponse(ctx, nil, err)
 		return
@@ -0,0 +1,56 @@
+package controller
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"wikirace/pkg/logic/api/apiv1"
+	"wikirace/pkg/stderror"
+)
+
+// AddBot implements /api/v1/bots/add
+func (a *APIV1) AddBot(ctx *gin.Context) {
+	var req apiv1.AddBotRequest
+	if err := ctx.Bind(&req); err != nil {
+		SendResponse(ctx, nil, stderror.New(stderror.ErrBind, err))
+		return
+	}
+	if req.GameCode == "" {
+		SendResponse(ctx, nil, stderror.New(stderror.ErrBadRequest, errors.New("gameCode is required")))
+		return
+	}
+	data, err := apiv1.AddBot(a.app, req)
+	if err != nil {
+		SendResponse(ctx, nil, err)
+		return
+	}
+	SendResponse(ctx, data, nil)
+}
+
+// RemoveBot implements /api/v1/bots/remove
+func (a *APIV1) RemoveBot(ctx *gin.Context) {
+	var req apiv1.RemoveBotRequest
+	if err := ctx.Bind(&req); err != nil {
+		SendResponse(ctx, nil, stderror.New(stderror.ErrBind, err))
+		return
+	}
+	if req.GameCode == "" || req.PlayerID == "" {
+		SendResponse(ctx, nil, stderror.New(stderror.ErrBadRequest, errors.New("gameCode and playerID are required")))
+		return
+	}
+	data, err := apiv1.RemoveBot(a.app, req)
+	if err != nil {
+		SendResponse(ctx, nil, err)
+		return
+	}
+	SendResponse(ctx, data, nil)
+}
+
+// ListBotStrategies implements /api/v1/bots/strategies
+func (a *APIV1) ListBotStrategies(ctx *gin.Context) {
+	data, err := apiv1.ListBotStrategies(a.app)
+	if err != nil {
+		SendResponse(ctx, nil, err)
+		return
+	}
+	SendResponse(ctx, data, nil)
+}
@@ -0,0 +1,89 @@
+package controller
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"wikirace/pkg/logic/api/apiv1"
+	"wikirace/pkg/stderror"
+)
+
+// GetDailyChallenge implements /api/v1/daily/challenge
+func (a *APIV1) GetDailyChallenge(ctx *gin.Context) {
+	data, err := apiv1.GetDailyChallenge(a.app, ctx.Query("date"))
+	if err != nil {
+		SendResponse(ctx, nil, err)
+		return
+	}
+	SendResponse(ctx, data, nil)
+}
+
+// StartDaily implements /api/v1/daily/start
+func (a *APIV1) StartDaily(ctx *gin.Context) {
+	var req apiv1.StartDailyRequest
+	if err := ctx.Bind(&req); err != nil {
+		SendResponse(ctx, nil, stderror.New(stderror.ErrBind, err))
+		return
+	}
+	if req.PlayerID == "" && req.AccountID == "" {
+		SendResponse(ctx, nil, stderror.New(stderror.ErrBadRequest, errors.New("playerID is required")))
+		return
+	}
+	data, err := apiv1.StartDaily(a.app, req)
+	if err != nil {
+		SendResponse(ctx, nil, err)
+		return
+	}
+	SendResponse(ctx, data, nil)
+}
+
+// DailyAddPath implements /api/v1/daily/addpath
+func (a *APIV1) DailyAddPath(ctx *gin.Context) {
+	var req apiv1.DailyAddPathRequest
+	if err := ctx.Bind(&req); err != nil {
+		SendResponse(ctx, nil, stderror.New(stderror.ErrBind, err))
+		return
+	}
+	if req.PlayerID == "" && req.AccountID == "" {
+		SendResponse(ctx, nil, stderror.New(stderror.ErrBadRequest, errors.New("playerID is required")))
+		return
+	}
+	data, err := apiv1.DailyAddPath(a.app, req)
+	if err != nil {
+		SendResponse(ctx, nil, err)
+		return
+	}
+	SendResponse(ctx, data, nil)
+}
+
+// GetDailyLeaderboard implements /api/v1/daily/leaderboard
+func (a *APIV1) GetDailyLeaderboard(ctx *gin.Context) {
+	var req apiv1.DailyLeaderboardRequest
+	if err := ctx.Bind(&req); err != nil {
+		SendResponse(ctx, nil, stderror.New(stderror.ErrBind, err))
+		return
+	}
+	if req.Limit <= 0 || req.Limit > maxPageSize {
+		req.Limit = maxPageSize
+	}
+	data, err := apiv1.GetDailyLeaderboard(a.app, req)
+	if err != nil {
+		SendResponse(ctx, nil, err)
+		return
+	}
+	SendResponse(ctx, data, nil)
+}
+
+// GetDailyStreak implements /api/v1/daily/streak
+func (a *APIV1) GetDailyStreak(ctx *gin.Context) {
+	playerID := ctx.Query("playerID")
+	if playerID == "" {
+		SendResponse(ctx, nil, stderror.New(stderror.ErrBadRequest, errors.New("playerID is required")))
+		return
+	}
+	data, err := apiv1.GetDailyStreak(a.app, playerID)
+	if err != nil {
+		SendResponse(ctx, nil, err)
+		return
+	}
+	SendResponse(ctx, data, nil)
+}
@@ -0,0 +1,44 @@
+package controller
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"wikirace/pkg/logic/api/apiv1"
+	"wikirace/pkg/stderror"
+)
+
+// maxHintLinks caps the number of links a hint request may rank
+const maxHintLinks = 1000
+
+// GetHints implements /api/v1/hints
+func (a *APIV1) GetHints(ctx *gin.Context) {
+	var req apiv1.HintsRequest
+	if err := ctx.Bind(&req); err != nil {
+		SendResponse(ctx, nil, stderror.New(stderror.ErrBind, err))
+		return
+	}
+	if req.GameCode != "" && req.PlayerID == "" {
+		SendResponse(ctx, nil, stderror.New(stderror.ErrBadRequest, errors.New("playerID is required with gameCode")))
+		return
+	}
+	if len(req.Links) > maxHintLinks {
+		SendResponse(ctx, nil, stderror.New(stderror.ErrBadRequest, errors.New("too many links")))
+		return
+	}
+	data, err := apiv1.GetHints(a.app, req)
+	if err != nil {
+		SendResponse(ctx, nil, err)
+		return
+	}
+	SendResponse(ctx, data, nil)
+}
+
+// ListHintProviders implements /api/v1/hints/providers
+func (a *APIV1) ListHintProviders(ctx *gin.Context) {
+	data, err := apiv1.ListHintProviders(a.app)
+	if err != nil {
+		SendResponse(ctx, nil, err)
+		return
+	}
+	SendResponse(ctx, data, nil)
+}
@@ -0,0 +1,45 @@
+package controller
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"wikirace/pkg/logic/api/apiv1"
+	"wikirace/pkg/stderror"
+)
+
+// GetLeaderboard implements /api/v1/leaderboards
+func (a *APIV1) GetLeaderboard(ctx *gin.Context) {
+	var req apiv1.LeaderboardRequest
+	if err := ctx.Bind(&req); err != nil {
+		SendResponse(ctx, nil, stderror.New(stderror.ErrBind, err))
+		return
+	}
+	if req.Limit <= 0 || req.Limit > maxPageSize {
+		req.Limit = maxPageSize
+	}
+	data, err := apiv1.GetLeaderboard(a.app, req)
+	if err != nil {
+		SendResponse(ctx, nil, err)
+		return
+	}
+	SendResponse(ctx, data, nil)
+}
+
+// GetPlayerLeaderboard implements /api/v1/leaderboards/player
+func (a *APIV1) GetPlayerLeaderboard(ctx *gin.Context) {
+	var req apiv1.LeaderboardRequest
+	if err := ctx.Bind(&req); err != nil {
+		SendResponse(ctx, nil, stderror.New(stderror.ErrBind, err))
+		return
+	}
+	if req.PlayerID == "" {
+		SendResponse(ctx, nil, stderror.New(stderror.ErrBadRequest, errors.New("playerID is required")))
+		return
+	}
+	data, err := apiv1.GetPlayerLeaderboard(a.app, req)
+	if err != nil {
+		SendResponse(ctx, nil, err)
+		return
+	}
+	SendResponse(ctx, data, nil)
+}
@@ -0,0 +1,80 @@
+package controller
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"wikirace/pkg/logic/api/apiv1"
+	"wikirace/pkg/stderror"
+)
+
+// ListLobbies implements /api/v1/games/list
+func (a *APIV1) ListLobbies(ctx *gin.Context) {
+	var req apiv1.ListLobbiesRequest
+	if err := ctx.Bind(&req); err != nil {
+		SendResponse(ctx, nil, stderror.New(stderror.ErrBind, err))
+		return
+	}
+	if req.Limit <= 0 || req.Limit > maxPageSize {
+		req.Limit = maxPageSize
+	}
+	data, err := apiv1.ListLobbies(a.app, req)
+	if err != nil {
+		SendResponse(ctx, nil, err)
+		return
+	}
+	SendResponse(ctx, data, nil)
+}
+
+// JoinMatchmaking implements /api/v1/matchmaking/join
+func (a *APIV1) JoinMatchmaking(ctx *gin.Context) {
+	var req apiv1.JoinMatchmakingRequest
+	if err := ctx.Bind(&req); err != nil {
+		SendResponse(ctx, nil, stderror.New(stderror.ErrBind, err))
+		return
+	}
+	if req.PlayerID == "" {
+		SendResponse(ctx, nil, stderror.New(stderror.ErrBadRequest, errors.New("playerID is required")))
+		return
+	}
+	req.ClientIP = ctx.ClientIP()
+	data, err := apiv1.JoinMatchmaking(a.app, req)
+	if err != nil {
+		SendResponse(ctx, nil, err)
+		return
+	}
+	SendResponse(ctx, data, nil)
+}
+
+// GetMatchmakingStatus implements /api/v1/matchmaking/status
+func (a *APIV1) GetMatchmakingStatus(ctx *gin.Context) {
+	playerID := ctx.Query("playerID")
+	if playerID == "" {
+		SendResponse(ctx, nil, stderror.New(stderror.ErrBadRequest, errors.New("playerID is required")))
+		return
+	}
+	data, err := apiv1.GetMatchmakingStatus(a.app, playerID)
+	if err != nil {
+		SendResponse(ctx, nil, err)
+		return
+	}
+	SendResponse(ctx, data, nil)
+}
+
+// LeaveMatchmaking implements /api/v1/matchmaking/leave
+func (a *APIV1) LeaveMatchmaking(ctx *gin.Context) {
+	var req apiv1.LeaveMatchmakingRequest
+	if err := ctx.Bind(&req); err != nil {
+		SendResponse(ctx, nil, stderror.New(stderror.ErrBind, err))
+		return
+	}
+	if req.PlayerID == "" {
+		SendResponse(ctx, nil, stderror.New(stderror.ErrBadRequest, errors.New("playerID is required")))
+		return
+	}
+	data, err := apiv1.LeaveMatchmaking(a.app, req)
+	if err != nil {
+		SendResponse(ctx, nil, err)
+		return
+	}
+	SendResponse(ctx, data, nil)
+}
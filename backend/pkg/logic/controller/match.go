@@ -0,0 +1,66 @@
+package controller
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"wikirace/pkg/logic/api/apiv1"
+	"wikirace/pkg/stderror"
+)
+
+const maxPageSize = 100
+
+// ListMatches implements /api/v1/matches/list
+func (a *APIV1) ListMatches(ctx *gin.Context) {
+	var req apiv1.ListMatchesRequest
+	if err := ctx.Bind(&req); err != nil {
+		SendResponse(ctx, nil, stderror.New(stderror.ErrBind, err))
+		return
+	}
+	if req.PlayerID == "" {
+		SendResponse(ctx, nil, stderror.New(stderror.ErrBadRequest, errors.New("playerID is required")))
+		return
+	}
+	if req.Limit <= 0 || req.Limit > maxPageSize {
+		req.Limit = maxPageSize
+	}
+	data, err := apiv1.ListMatches(a.app, req)
+	if err != nil {
+		SendResponse(ctx, nil, err)
+		return
+	}
+	SendResponse(ctx, data, nil)
+}
+
+// GetMatch implements /api/v1/matches/info
+func (a *APIV1) GetMatch(ctx *gin.Context) {
+	matchID := ctx.Query("matchID")
+	if matchID == "" {
+		SendResponse(ctx, nil, stderror.New(stderror.ErrBadRequest, errors.New("matchID is required")))
+		return
+	}
+	data, err := apiv1.GetMatch(a.app, matchID)
+	if err != nil {
+		SendResponse(ctx, nil, err)
+		return
+	}
+	SendResponse(ctx, data, nil)
+}
+
+// ReviewMatch implements /api/v1/matches/review
+func (a *APIV1) ReviewMatch(ctx *gin.Context) {
+	var req apiv1.ReviewMatchRequest
+	if err := ctx.Bind(&req); err != nil {
+		SendResponse(ctx, nil, stderror.New(stderror.ErrBind, err))
+		return
+	}
+	if req.GameCode == "" || req.PlayerID == "" {
+		SendResponse(ctx, nil, stderror.New(stderror.ErrBadRequest, errors.New("gameCode and playerID are required")))
+		return
+	}
+	data, err := apiv1.ReviewMatch(a.app, req)
+	if err != nil {
+		SendResponse(ctx, nil, err)
+		return
+	}
+	SendResponse(ctx, data, nil)
+}
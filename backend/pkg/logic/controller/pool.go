@@ -0,0 +1,109 @@
+package controller
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"wikirace/pkg/logic/api/apiv1"
+	"wikirace/pkg/stderror"
+)
+
+// CreatePool implements /api/v1/pools/create
+func (a *APIV1) CreatePool(ctx *gin.Context) {
+	var req apiv1.CreatePoolRequest
+	if err := ctx.Bind(&req); err != nil {
+		SendResponse(ctx, nil, stderror.New(stderror.ErrBind, err))
+		return
+	}
+	data, err := apiv1.CreatePool(a.app, req)
+	if err != nil {
+		SendResponse(ctx, nil, err)
+		return
+	}
+	SendResponse(ctx, data, nil)
+}
+
+// GetPool implements /api/v1/pools/info
+func (a *APIV1) GetPool(ctx *gin.Context) {
+	poolID := ctx.Query("poolID")
+	if poolID == "" {
+		SendResponse(ctx, nil, stderror.New(stderror.ErrBadRequest, errors.New("poolID is required")))
+		return
+	}
+	data, err := apiv1.GetPool(a.app, poolID)
+	if err != nil {
+		SendResponse(ctx, nil, err)
+		return
+	}
+	SendResponse(ctx, data, nil)
+}
+
+// ListPools implements /api/v1/pools/list
+func (a *APIV1) ListPools(ctx *gin.Context) {
+	var req apiv1.ListPoolsRequest
+	if err := ctx.Bind(&req); err != nil {
+		SendResponse(ctx, nil, stderror.New(stderror.ErrBind, err))
+		return
+	}
+	if req.Limit <= 0 || req.Limit > maxPageSize {
+		req.Limit = maxPageSize
+	}
+	data, err := apiv1.ListPools(a.app, req)
+	if err != nil {
+		SendResponse(ctx, nil, err)
+		return
+	}
+	SendResponse(ctx, data, nil)
+}
+
+// UpdatePool implements /api/v1/pools/update
+func (a *APIV1) UpdatePool(ctx *gin.Context) {
+	var req apiv1.UpdatePoolRequest
+	if err := ctx.Bind(&req); err != nil {
+		SendResponse(ctx, nil, stderror.New(stderror.ErrBind, err))
+		return
+	}
+	if req.PoolID == "" {
+		SendResponse(ctx, nil, stderror.New(stderror.ErrBadRequest, errors.New("poolID is required")))
+		return
+	}
+	data, err := apiv1.UpdatePool(a.app, req)
+	if err != nil {
+		SendResponse(ctx, nil, err)
+		return
+	}
+	SendResponse(ctx, data, nil)
+}
+
+// DeletePool implements /api/v1/pools/delete
+func (a *APIV1) DeletePool(ctx *gin.Context) {
+	var req apiv1.DeletePoolRequest
+	if err := ctx.Bind(&req); err != nil {
+		SendResponse(ctx, nil, stderror.New(stderror.ErrBind, err))
+		return
+	}
+	if req.PoolID == "" {
+		SendResponse(ctx, nil, stderror.New(stderror.ErrBadRequest, errors.New("poolID is required")))
+		return
+	}
+	data, err := apiv1.DeletePool(a.app, req)
+	if err != nil {
+		SendResponse(ctx, nil, err)
+		return
+	}
+	SendResponse(ctx, data, nil)
+}
+
+// ImportPool implements /api/v1/pools/import
+func (a *APIV1) ImportPool(ctx *gin.Context) {
+	var req apiv1.ImportPoolRequest
+	if err := ctx.Bind(&req); err != nil {
+		SendResponse(ctx, nil, stderror.New(stderror.ErrBind, err))
+		return
+	}
+	data, err := apiv1.ImportPool(a.app, req)
+	if err != nil {
+		SendResponse(ctx, nil, err)
+		return
+	}
+	SendResponse(ctx, data, nil)
+}
@@ -0,0 +1,58 @@
+package controller
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"wikirace/pkg/logic/api/apiv1"
+	"wikirace/pkg/replay"
+	"wikirace/pkg/stderror"
+)
+
+// ExportReplay implements /api/v1/replays/export
+func (a *APIV1) ExportReplay(ctx *gin.Context) {
+	var req apiv1.ExportReplayRequest
+	if err := ctx.Bind(&req); err != nil {
+		SendResponse(ctx, nil, stderror.New(stderror.ErrBind, err))
+		return
+	}
+	if req.GameCode == "" && req.MatchID == "" {
+		SendResponse(ctx, nil, stderror.New(stderror.ErrBadRequest, errors.New("gameCode or matchID is required")))
+		return
+	}
+	data, err := apiv1.ExportReplay(a.app, req)
+	if err != nil {
+		SendResponse(ctx, nil, err)
+		return
+	}
+	SendResponse(ctx, data, nil)
+}
+
+// ImportReplay implements /api/v1/replays/import
+func (a *APIV1) ImportReplay(ctx *gin.Context) {
+	var req replay.Replay
+	if err := ctx.Bind(&req); err != nil {
+		SendResponse(ctx, nil, stderror.New(stderror.ErrBind, err))
+		return
+	}
+	data, err := apiv1.ImportReplay(a.app, req)
+	if err != nil {
+		SendResponse(ctx, nil, err)
+		return
+	}
+	SendResponse(ctx, data, nil)
+}
+
+// GetReplay implements /api/v1/replays/info
+func (a *APIV1) GetReplay(ctx *gin.Context) {
+	replayID := ctx.Query("replayID")
+	if replayID == "" {
+		SendResponse(ctx, nil, stderror.New(stderror.ErrBadRequest, errors.New("replayID is required")))
+		return
+	}
+	data, err := apiv1.GetReplay(a.app, replayID)
+	if err != nil {
+		SendResponse(ctx, nil, err)
+		return
+	}
+	SendResponse(ctx, data, nil)
+}
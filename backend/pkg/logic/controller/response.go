@@ -14,6 +14,11 @@ type Response struct {
 	Data interface{} `json:"data"`
 }
 
+// ValidationErrors is the data of a response failing with stderror.ErrValidation
+type ValidationErrors struct {
+	Errors stderror.FieldErrors `json:"errors"`
+}
+
 func SendResponse(c *gin.Context, data interface{}, err error) {
 	// intercept the error and log it
 	if err != nil {
@@ -21,6 +26,10 @@ func SendResponse(c *gin.Context, data interface{}, err error) {
 	}
 	// return the standard error message instead of the original error message to frontend
 	code, msg := stderror.StandardizeError(err)
+	// validation errors tell the frontend which fields to fix
+	if fields := stderror.Fields(err); fields != nil {
+		data = ValidationErrors{Errors: fields}
+	}
 	// always return http.StatusOK
 	c.JSON(http.StatusOK, Response{
 		Code: code,
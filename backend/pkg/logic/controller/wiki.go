@@ -0,0 +1,43 @@
+package controller
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"wikirace/pkg/logic/api/apiv1"
+	"wikirace/pkg/stderror"
+)
+
+// GetArticle implements /api/v1/wiki/article
+func (a *APIV1) GetArticle(ctx *gin.Context) {
+	title := ctx.Query("title")
+	if title == "" {
+		SendResponse(ctx, nil, stderror.New(stderror.ErrBadRequest, errors.New("title is required")))
+		return
+	}
+	data, err := apiv1.GetArticle(a.app, ctx.Query("language"), title)
+	if err != nil {
+		SendResponse(ctx, nil, err)
+		return
+	}
+	SendResponse(ctx, data, nil)
+}
+
+// GetRandomArticle implements /api/v1/wiki/random
+func (a *APIV1) GetRandomArticle(ctx *gin.Context) {
+	data, err := apiv1.GetRandomArticle(a.app, ctx.Query("language"))
+	if err != nil {
+		SendResponse(ctx, nil, err)
+		return
+	}
+	SendResponse(ctx, data, nil)
+}
+
+// ListLanguages implements /api/v1/wiki/languages
+func (a *APIV1) ListLanguages(ctx *gin.Context) {
+	data, err := apiv1.ListLanguages(a.app)
+	if err != nil {
+		SendResponse(ctx, nil, err)
+		return
+	}
+	SendResponse(ctx, data, nil)
+}
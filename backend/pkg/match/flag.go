@@ -0,0 +1,28 @@
+package match
+
+const (
+	FlagSpeed      = "speed"        // two moves were closer together than a human can click
+	FlagNotLinked  = "not_linked"   // the article is not linked from the previous one
+	FlagTargetJump = "target_jump"  // the target was reached from an article that does not link to it
+	FlagOutOfOrder = "out_of_order" // the move times are decreasing, outside the round or more than the moves
+)
+
+// Flag marks a suspicious move found by the anti-cheat analysis of a match
+type Flag struct {
+	PlayerID string `json:"playerID"` // identity of the participant, see Participant.ID
+	Kind     string `json:"kind"`     // one of the Flag kinds
+	Move     int    `json:"move"`     // index of the move in the participant's paths
+	Article  string `json:"article"`
+	Detail   string `json:"detail"`
+}
+
+// FlagsOf returns the flags of a participant
+func (m *Match) FlagsOf(playerID string) []Flag {
+	flags := make([]Flag, 0)
+	for _, f := range m.Flags {
+		if f.PlayerID == playerID {
+			flags = append(flags, f)
+		}
+	}
+	return flags
+}
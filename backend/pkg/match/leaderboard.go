@@ -0,0 +1,199 @@
+package match
+
+import (
+	"errors"
+	"sort"
+	"time"
+	"wikirace/pkg/game"
+	"wikirace/pkg/stderror"
+)
+
+// Metric is the statistic a leaderboard is ranked by
+type Metric string
+
+const (
+	MetricFastestWin  Metric = "fastest" // shortest winning round
+	MetricFewestClick Metric = "fewest"  // fewest clicks in a winning round
+	MetricMostWins    Metric = "wins"    // number of rounds won
+	MetricWinRate     Metric = "winrate" // rounds won divided by rounds played
+)
+
+// windows maps the supported time windows to their length, a zero length means all time
+var windows = map[string]time.Duration{
+	"":      0,
+	"all":   0,
+	"day":   24 * time.Hour,
+	"week":  7 * 24 * time.Hour,
+	"month": 30 * 24 * time.Hour,
+	"year":  365 * 24 * time.Hour,
+}
+
+// LeaderboardQuery selects and pages the matches a leaderboard is computed from
+type LeaderboardQuery struct {
+	Metric        Metric
+	Since         time.Time // only count matches that ended at or after Since, zero means no lower bound
+	StartArticle  string    // only count matches with this start article, empty means any
+	TargetArticle string    // only count matches with this target article, empty means any
+	MinPlayed     int       // players with fewer rounds played are left out of the win rate ranking
+	PlayerID      string    // only return the entry of this player, by identity
+	Offset        int
+	Limit         int
+}
+
+// LeaderboardEntry is a player's ranked statistics
+type LeaderboardEntry struct {
+	Rank         int           `json:"rank" bson:"-"`
+	PlayerID     string        `json:"playerID" bson:"_id"` // identity of the player, see Public
+	Name         string        `json:"name"`
+	Played       int           `json:"played"`
+	Wins         int           `json:"wins"`
+	WinRate      float64       `json:"winRate"`
+	BestDuration time.Duration `json:"bestDuration"` // including hint penalties, zero if the player has not won
+	FewestClicks int           `json:"fewestClicks"` // zero if the player has not won
+}
+
+// Public returns the entry as shown on public leaderboards, with the player's PublicID
+func (e LeaderboardEntry) Public() LeaderboardEntry {
+	e.PlayerID = PublicID(e.PlayerID)
+	return e
+}
+
+// ParseMetric validates a metric name
+func ParseMetric(metric string) (Metric, error) {
+	switch m := Metric(metric); m {
+	case MetricFastestWin, MetricFewestClick, MetricMostWins, MetricWinRate:
+		return m, nil
+	default:
+		return "", stderror.New(stderror.ErrBadRequest, errors.New("unknown leaderboard metric: "+metric))
+	}
+}
+
+// WindowStart returns the beginning of a named time window ending now,
+// the zero time is returned for an all-time window
+func WindowStart(window string, now time.Time) (time.Time, error) {
+	d, ok := windows[window]
+	if !ok {
+		return time.Time{}, stderror.New(stderror.ErrBadRequest, errors.New("unknown leaderboard window: "+window))
+	}
+	if d == 0 {
+		return time.Time{}, nil
+	}
+	return now.Add(-d), nil
+}
+
+// matches reports whether a match is counted by the query
+func (q LeaderboardQuery) matches(m Match) bool {
+	// practice rounds only count towards personal match history
+	if m.Type == game.TypeSolo {
+		return false
+	}
+	if !q.Since.IsZero() && m.EndTime.Before(q.Since) {
+		return false
+	}
+	if q.StartArticle != "" && m.StartArticle != q.StartArticle {
+		return false
+	}
+	if q.TargetArticle != "" && m.TargetArticle != q.TargetArticle {
+		return false
+	}
+	return true
+}
+
+// qualifies reports whether an entry belongs on the leaderboard of the query's metric
+func (q LeaderboardQuery) qualifies(e LeaderboardEntry) bool {
+	if q.Metric == MetricWinRate {
+		return e.Played > 0 && e.Played >= q.MinPlayed
+	}
+	return e.Wins > 0
+}
+
+// less orders two entries by the query's metric, ties are broken by player ID
+func (q LeaderboardQuery) less(a, b LeaderboardEntry) bool {
+	switch q.Metric {
+	case MetricFastestWin:
+		if a.BestDuration != b.BestDuration {
+			return a.BestDuration < b.BestDuration
+		}
+	case MetricFewestClick:
+		if a.FewestClicks != b.FewestClicks {
+			return a.FewestClicks < b.FewestClicks
+		}
+	case MetricMostWins:
+		if a.Wins != b.Wins {
+			return a.Wins > b.Wins
+		}
+	case MetricWinRate:
+		if a.WinRate != b.WinRate {
+			return a.WinRate > b.WinRate
+		}
+		if a.Played != b.Played {
+			return a.Played > b.Played
+		}
+	}
+	return a.PlayerID < b.PlayerID
+}
+
+// rank computes the leaderboard of a set of matches in memory,
+// it mirrors the aggregation pipeline used by MongoStore
+func rank(matches []Match, q LeaderboardQuery) []LeaderboardEntry {
+	// process matches oldest first so the most recent display name wins
+	sort.Slice(matches, func(i, j int) bool {
+		return matches[i].EndTime.Before(matches[j].EndTime)
+	})
+	byPlayer := make(map[string]*LeaderboardEntry)
+	for _, m := range matches {
+		if !q.matches(m) {
+			continue
+		}
+		for _, p := range m.Players {
+			if p.Bot != "" {
+				continue
+			}
+			e, ok := byPlayer[p.ID]
+			if !ok {
+				e = &LeaderboardEntry{PlayerID: p.ID}
+				byPlayer[p.ID] = e
+			}
+			e.Name = p.Name
+			e.Played++
+			if !p.IsWinner {
+				continue
+			}
+			e.Wins++
+			if t := p.Time(&m); e.BestDuration == 0 || t < e.BestDuration {
+				e.BestDuration = t
+			}
+			if e.FewestClicks == 0 || p.Clicks < e.FewestClicks {
+				e.FewestClicks = p.Clicks
+			}
+		}
+	}
+
+	entries := make([]LeaderboardEntry, 0, len(byPlayer))
+	for _, e := range byPlayer {
+		e.WinRate = float64(e.Wins) / float64(e.Played)
+		if q.qualifies(*e) {
+			entries = append(entries, *e)
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return q.less(entries[i], entries[j])
+	})
+	for i := range entries {
+		entries[i].Rank = i + 1
+	}
+	return entries
+}
+
+// pageEntries applies the query's player filter or pagination to a ranked leaderboard
+func pageEntries(entries []LeaderboardEntry, q LeaderboardQuery) []LeaderboardEntry {
+	if q.PlayerID != "" {
+		for _, e := range entries {
+			if e.PlayerID == q.PlayerID {
+				return []LeaderboardEntry{e}
+			}
+		}
+		return []LeaderboardEntry{}
+	}
+	return paginate(entries, q.Offset, q.Limit)
+}
@@ -0,0 +1,116 @@
+package match
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+	"wikirace/pkg/game"
+)
+
+// Match is the archived result of a single finished round of a game
+type Match struct {
+	ID            string        `json:"id"`
+	GameCode      string        `json:"gameCode"`
+	Type          string        `json:"type"`               // type of the game the match was played in, see game.TypeSolo
+	Language      string        `json:"language,omitempty"` // Wikipedia the match was played on
+	StartArticle  string        `json:"startArticle"`
+	TargetArticle string        `json:"targetArticle"`
+	StartTime     time.Time     `json:"startTime"`
+	EndTime       time.Time     `json:"endTime"`
+	Duration      time.Duration `json:"duration"` // time between start and end of the round
+	WinnerID      string        `json:"winnerID"`
+	Players       []Participant `json:"players"`
+	Flags         []Flag        `json:"-"` // found by the anti-cheat analysis, only shown to the leader of the game
+}
+
+// Participant is a player's result in an archived match
+type Participant struct {
+	ID        string        `json:"id"` // identity of the player, see game.Player.Identity
+	Name      string        `json:"name"`
+	IsWinner  bool          `json:"isWinner"`
+	Bot       string        `json:"bot,omitempty"` // strategy of a bot, bots are not ranked on leaderboards or rated
+	Paths     []string      `json:"paths"`         // list of article names
+	PathTimes []time.Time   `json:"pathTimes"`     // time each article in Paths was reached
+	Clicks    int           `json:"clicks"`
+	HintsUsed int           `json:"hintsUsed"`
+	Penalty   time.Duration `json:"penalty"`          // hint penalty added to the player's time
+	Voided    bool          `json:"voided,omitempty"` // the player reached the target first but the win was voided by the anti-cheat analysis
+}
+
+// Time returns the time the player took to win the match, including the hint penalty
+func (p Participant) Time(m *Match) time.Duration {
+	return m.Duration + p.Penalty
+}
+
+// MatchID derives the ID of the match played in the current round of a game,
+// so archiving the same round twice always refers to the same match
+func MatchID(g *game.Game) string {
+	return fmt.Sprintf("%s-%d", g.Code, g.StartTime.UnixMilli())
+}
+
+// FromGame builds a match from a finished game
+func FromGame(g *game.Game) *Match {
+	m := &Match{
+		ID:            MatchID(g),
+		GameCode:      g.Code,
+		Type:          g.Type,
+		Language:      g.Language,
+		StartArticle:  g.StartArticle,
+		TargetArticle: g.TargetArticle,
+		StartTime:     g.StartTime,
+		EndTime:       g.EndTime,
+		Duration:      g.EndTime.Sub(g.StartTime),
+		Players:       make([]Participant, 0, len(g.Players)),
+	}
+	for _, p := range g.Players {
+		paths := append([]string{}, p.Paths...)
+		pathTimes := append([]time.Time{}, p.PathTimes...)
+		m.Players = append(m.Players, Participant{
+			ID:        p.Identity(),
+			Name:      p.Name,
+			IsWinner:  p.IsWinner,
+			Bot:       botStrategy(p),
+			Paths:     paths,
+			PathTimes: pathTimes,
+			Clicks:    len(paths),
+			HintsUsed: p.HintsUsed,
+			Penalty:   time.Duration(p.HintsUsed) * g.HintPenalty,
+		})
+		if p.IsWinner {
+			m.WinnerID = p.Identity()
+		}
+	}
+	return m
+}
+
+// PublicID returns the ID a player is shown under in public results. Anonymous player IDs authenticate
+// their players, so results show a hash of the identity instead: players can still be told apart and
+// find their own entries, but nobody can act as them.
+func PublicID(identity string) string {
+	if identity == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(identity))
+	return hex.EncodeToString(sum[:8])
+}
+
+// Public returns a copy of the match as shown in the public match history, with the PublicID of every
+// participant and without the anti-cheat flags
+func (m Match) Public() Match {
+	m = clone(m)
+	m.WinnerID = PublicID(m.WinnerID)
+	for i := range m.Players {
+		m.Players[i].ID = PublicID(m.Players[i].ID)
+	}
+	m.Flags = []Flag{}
+	return m
+}
+
+// botStrategy returns the strategy of a bot player, or an empty string for humans
+func botStrategy(p game.Player) string {
+	if p.Bot == nil {
+		return ""
+	}
+	return p.Bot.Strategy
+}
@@ -0,0 +1,37 @@
+package match
+
+import "testing"
+
+func TestPublicID(t *testing.T) {
+	id := PublicID("player-secret")
+	if id == "" || id == "player-secret" || len(id) != 16 {
+		t.Fatalf("got %q, want a 16 character hash", id)
+	}
+	if PublicID("player-secret") != id {
+		t.Fatal("the same identity got different public IDs")
+	}
+	if PublicID("other-player") == id {
+		t.Fatal("different identities got the same public ID")
+	}
+	if PublicID("") != "" {
+		t.Fatal("an empty identity got a public ID")
+	}
+	if e := (LeaderboardEntry{PlayerID: "player-secret", Wins: 2}).Public(); e.PlayerID != id || e.Wins != 2 {
+		t.Fatalf("got public entry %+v", e)
+	}
+}
+
+func TestMatchPublic(t *testing.T) {
+	m := Match{WinnerID: "p1", Players: []Participant{{ID: "p1", IsWinner: true, Paths: []string{"Pizza"}}, {ID: "p2"}},
+		Flags: []Flag{{PlayerID: "p1", Kind: FlagSpeed}}}
+	public := m.Public()
+	if public.WinnerID != PublicID("p1") || public.Players[0].ID != PublicID("p1") || public.Players[1].ID != PublicID("p2") {
+		t.Fatalf("got public match %+v", public)
+	}
+	if len(public.Flags) != 0 || len(public.Players[0].Paths) != 1 {
+		t.Fatalf("got flags %+v and paths %v", public.Flags, public.Players[0].Paths)
+	}
+	if m.Players[0].ID != "p1" || m.WinnerID != "p1" {
+		t.Fatal("Public changed the match")
+	}
+}
@@ -0,0 +1,167 @@
+package match
+
+import (
+	"context"
+	"errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"wikirace/pkg/game"
+	"wikirace/pkg/logger"
+	"wikirace/pkg/mongodb"
+	"wikirace/pkg/stderror"
+)
+
+// MongoStore is a Store backed by the matches collection
+type MongoStore struct {
+	collection *mongo.Collection
+}
+
+// NewMongoStore creates a MongoStore using the given client
+func NewMongoStore(db *mongo.Client) *MongoStore {
+	return &MongoStore{
+		collection: mongodb.GetCollection(db, "wikirace", "matches"),
+	}
+}
+
+func (s *MongoStore) Save(m *Match) (bool, error) {
+	// only insert the match if it does not exist yet, so archiving a round twice is a no-op
+	filter := bson.M{"id": m.ID}
+	update := bson.M{"$setOnInsert": m}
+	result, err := s.collection.UpdateOne(nil, filter, update, options.Update().SetUpsert(true))
+	if err != nil {
+		return false, err
+	}
+	return result.UpsertedCount > 0, nil
+}
+
+func (s *MongoStore) Update(m *Match) error {
+	filter := bson.M{"id": m.ID}
+	result, err := s.collection.ReplaceOne(nil, filter, m)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return stderror.New(stderror.ErrMatchNotFound, errors.New("match not found, id: "+m.ID))
+	}
+	return nil
+}
+
+func (s *MongoStore) Get(id string) (*Match, error) {
+	filter := bson.M{"id": id}
+	m := Match{}
+	err := s.collection.FindOne(nil, filter).Decode(&m)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			logger.Debugf("match not found: %v", id)
+			return nil, stderror.New(stderror.ErrMatchNotFound, errors.New("match not found, id: "+id))
+		}
+		return nil, err
+	}
+	return &m, nil
+}
+
+func (s *MongoStore) ListByPlayer(playerID string, offset, limit int) ([]Match, error) {
+	filter := bson.M{"players.id": playerID}
+	opts := options.Find().SetSort(bson.D{{Key: "endtime", Value: -1}})
+	if offset > 0 {
+		opts.SetSkip(int64(offset))
+	}
+	if limit > 0 {
+		opts.SetLimit(int64(limit))
+	}
+	cursor, err := s.collection.Find(nil, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	matches := make([]Match, 0)
+	if err = cursor.All(context.Background(), &matches); err != nil {
+		return nil, err
+	}
+	return matches, nil
+}
+
+func (s *MongoStore) Leaderboard(q LeaderboardQuery) ([]LeaderboardEntry, error) {
+	// select the matches counted by the query
+	// practice rounds only count towards personal match history
+	filter := bson.M{"type": bson.M{"$ne": game.TypeSolo}}
+	if !q.Since.IsZero() {
+		filter["endtime"] = bson.M{"$gte": q.Since}
+	}
+	if q.StartArticle != "" {
+		filter["startarticle"] = q.StartArticle
+	}
+	if q.TargetArticle != "" {
+		filter["targetarticle"] = q.TargetArticle
+	}
+	// $min ignores nulls, so only winning rounds count towards the best duration and fewest clicks
+	winnerOnly := func(field interface{}) bson.M {
+		return bson.M{"$cond": bson.A{"$players.iswinner", field, nil}}
+	}
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: filter}},
+		// sort oldest first so $last picks the most recent display name
+		{{Key: "$sort", Value: bson.D{{Key: "endtime", Value: 1}}}},
+		{{Key: "$unwind", Value: "$players"}},
+		// bots are not ranked, matches archived before bots have no bot field
+		{{Key: "$match", Value: bson.M{"players.bot": bson.M{"$in": bson.A{nil, ""}}}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":    "$players.id",
+			"name":   bson.M{"$last": "$players.name"},
+			"played": bson.M{"$sum": 1},
+			"wins":   bson.M{"$sum": bson.M{"$cond": bson.A{"$players.iswinner", 1, 0}}},
+			// matches archived before hint penalties have no penalty field
+			"bestduration": bson.M{"$min": winnerOnly(bson.M{"$add": bson.A{"$duration", bson.M{"$ifNull": bson.A{"$players.penalty", 0}}}})},
+			"fewestclicks": bson.M{"$min": winnerOnly("$players.clicks")},
+		}}},
+		{{Key: "$addFields", Value: bson.M{"winrate": bson.M{"$divide": bson.A{"$wins", "$played"}}}}},
+	}
+
+	// keep the players that qualify for the metric and order them
+	var sortBy bson.D
+	switch q.Metric {
+	case MetricFastestWin:
+		pipeline = append(pipeline, bson.D{{Key: "$match", Value: bson.M{"wins": bson.M{"$gt": 0}}}})
+		sortBy = bson.D{{Key: "bestduration", Value: 1}}
+	case MetricFewestClick:
+		pipeline = append(pipeline, bson.D{{Key: "$match", Value: bson.M{"wins": bson.M{"$gt": 0}}}})
+		sortBy = bson.D{{Key: "fewestclicks", Value: 1}}
+	case MetricMostWins:
+		pipeline = append(pipeline, bson.D{{Key: "$match", Value: bson.M{"wins": bson.M{"$gt": 0}}}})
+		sortBy = bson.D{{Key: "wins", Value: -1}}
+	case MetricWinRate:
+		pipeline = append(pipeline, bson.D{{Key: "$match", Value: bson.M{"played": bson.M{"$gte": max(q.MinPlayed, 1)}}}})
+		sortBy = bson.D{{Key: "winrate", Value: -1}, {Key: "played", Value: -1}}
+	}
+	sortBy = append(sortBy, bson.E{Key: "_id", Value: 1})
+	pipeline = append(pipeline, bson.D{{Key: "$sort", Value: sortBy}})
+
+	// a single player's entry needs the full ranking to know its rank
+	offset := q.Offset
+	if q.PlayerID == "" {
+		if offset > 0 {
+			pipeline = append(pipeline, bson.D{{Key: "$skip", Value: offset}})
+		}
+		if q.Limit > 0 {
+			pipeline = append(pipeline, bson.D{{Key: "$limit", Value: q.Limit}})
+		}
+	} else {
+		offset = 0
+	}
+
+	cursor, err := s.collection.Aggregate(nil, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]LeaderboardEntry, 0)
+	if err = cursor.All(context.Background(), &entries); err != nil {
+		return nil, err
+	}
+	for i := range entries {
+		entries[i].Rank = max(offset, 0) + i + 1
+	}
+	if q.PlayerID != "" {
+		return pageEntries(entries, q), nil
+	}
+	return entries, nil
+}
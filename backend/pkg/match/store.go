@@ -0,0 +1,123 @@
+package match
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"time"
+	"wikirace/pkg/stderror"
+)
+
+// Store persists archived matches
+type Store interface {
+	// Save stores a match if it has not been archived yet and reports whether it was inserted
+	Save(m *Match) (bool, error)
+	// Update replaces an archived match, e.g. with the results of the anti-cheat analysis
+	Update(m *Match) error
+	// Get returns a match by its ID
+	Get(id string) (*Match, error)
+	// ListByPlayer returns the matches a player took part in, most recent first
+	ListByPlayer(playerID string, offset, limit int) ([]Match, error)
+	// Leaderboard ranks players by the query's metric over the matches selected by the query
+	Leaderboard(q LeaderboardQuery) ([]LeaderboardEntry, error)
+}
+
+// MemoryStore is an in-memory Store, used for tests and local development
+type MemoryStore struct {
+	mu      sync.RWMutex
+	matches map[string]Match
+}
+
+// NewMemoryStore creates an empty MemoryStore
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		matches: make(map[string]Match),
+	}
+}
+
+func (s *MemoryStore) Save(m *Match) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.matches[m.ID]; ok {
+		return false, nil
+	}
+	s.matches[m.ID] = clone(*m)
+	return true, nil
+}
+
+func (s *MemoryStore) Update(m *Match) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.matches[m.ID]; !ok {
+		return stderror.New(stderror.ErrMatchNotFound, errors.New("match not found, id: "+m.ID))
+	}
+	s.matches[m.ID] = clone(*m)
+	return nil
+}
+
+func (s *MemoryStore) Get(id string) (*Match, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	m, ok := s.matches[id]
+	if !ok {
+		return nil, stderror.New(stderror.ErrMatchNotFound, errors.New("match not found, id: "+id))
+	}
+	m = clone(m)
+	return &m, nil
+}
+
+func (s *MemoryStore) ListByPlayer(playerID string, offset, limit int) ([]Match, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	matches := make([]Match, 0)
+	for _, m := range s.matches {
+		for _, p := range m.Players {
+			if p.ID == playerID {
+				matches = append(matches, clone(m))
+				break
+			}
+		}
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		return matches[i].EndTime.After(matches[j].EndTime)
+	})
+	return paginate(matches, offset, limit), nil
+}
+
+func (s *MemoryStore) Leaderboard(q LeaderboardQuery) ([]LeaderboardEntry, error) {
+	s.mu.RLock()
+	matches := make([]Match, 0, len(s.matches))
+	for _, m := range s.matches {
+		matches = append(matches, m)
+	}
+	s.mu.RUnlock()
+	return pageEntries(rank(matches, q), q), nil
+}
+
+// clone copies a match so callers cannot modify the stored one
+func clone(m Match) Match {
+	players := make([]Participant, len(m.Players))
+	for i, p := range m.Players {
+		p.Paths = append([]string{}, p.Paths...)
+		p.PathTimes = append([]time.Time{}, p.PathTimes...)
+		players[i] = p
+	}
+	m.Players = players
+	m.Flags = append([]Flag{}, m.Flags...)
+	return m
+}
+
+// paginate returns the window [offset, offset+limit) of a slice, a non-positive limit means no limit
+func paginate[T any](items []T, offset, limit int) []T {
+	if offset < 0 {
+		offset = 0
+	}
+	if offset >= len(items) {
+		return []T{}
+	}
+	items = items[offset:]
+	if limit > 0 && limit < len(items) {
+		items = items[:limit]
+	}
+	return items
+}
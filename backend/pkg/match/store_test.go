@@ -0,0 +1,141 @@
+package match
+
+import (
+	"testing"
+	"time"
+	"wikirace/pkg/game"
+)
+
+// played builds a match that ended at end and lasted duration, winner is the ID of the winning participant
+func played(id string, end time.Time, duration time.Duration, winner string, players ...Participant) Match {
+	m := Match{ID: id, StartArticle: "Rome", TargetArticle: "Pizza", EndTime: end, Duration: duration, WinnerID: winner}
+	for _, p := range players {
+		p.IsWinner = p.ID == winner
+		m.Players = append(m.Players, p)
+	}
+	return m
+}
+
+func newStore(t *testing.T, matches ...Match) *MemoryStore {
+	s := NewMemoryStore()
+	for i := range matches {
+		if _, err := s.Save(&matches[i]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return s
+}
+
+func TestMemoryStoreSaveAndUpdate(t *testing.T) {
+	now := time.Now()
+	m := played("m1", now, time.Minute, "a", Participant{ID: "a", Paths: []string{"Italy"}})
+	s := NewMemoryStore()
+	if inserted, err := s.Save(&m); err != nil || !inserted {
+		t.Fatalf("first save: inserted %v, error %v", inserted, err)
+	}
+	if inserted, err := s.Save(&m); err != nil || inserted {
+		t.Fatalf("second save: inserted %v, error %v", inserted, err)
+	}
+
+	// stored matches are copies
+	m.Players[0].Paths[0] = "Greece"
+	got, err := s.Get("m1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Players[0].Paths[0] != "Italy" {
+		t.Fatalf("stored path changed to %v", got.Players[0].Paths[0])
+	}
+
+	got.Flags = []Flag{{PlayerID: "a"}}
+	if err = s.Update(got); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ = s.Get("m1"); len(got.Flags) != 1 {
+		t.Fatalf("got flags %+v after the update", got.Flags)
+	}
+	if err = s.Update(&Match{ID: "missing"}); err == nil {
+		t.Fatal("updated a match that was never saved")
+	}
+	if _, err = s.Get("missing"); err == nil {
+		t.Fatal("got a match that was never saved")
+	}
+}
+
+func TestMemoryStoreListByPlayer(t *testing.T) {
+	now := time.Now()
+	s := newStore(t,
+		played("old", now.Add(-time.Hour), time.Minute, "a", Participant{ID: "a"}, Participant{ID: "b"}),
+		played("new", now, time.Minute, "b", Participant{ID: "a"}, Participant{ID: "b"}),
+		played("other", now, time.Minute, "c", Participant{ID: "c"}),
+	)
+	matches, err := s.ListByPlayer("a", 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(matches) != 2 || matches[0].ID != "new" || matches[1].ID != "old" {
+		t.Fatalf("got %+v, want the matches of a most recent first", matches)
+	}
+	if matches, _ = s.ListByPlayer("a", 1, 1); len(matches) != 1 || matches[0].ID != "old" {
+		t.Fatalf("second page: got %+v", matches)
+	}
+}
+
+func TestMemoryStoreLeaderboard(t *testing.T) {
+	now := time.Now()
+	solo := played("solo", now, time.Second, "c", Participant{ID: "c"})
+	solo.Type = game.TypeSolo
+	elsewhere := played("elsewhere", now.Add(-time.Minute), 2*time.Minute, "b", Participant{ID: "a"}, Participant{ID: "b"})
+	elsewhere.TargetArticle = "Pasta"
+	s := newStore(t,
+		// m2 ends last, so its name is shown
+		played("m1", now.Add(-48*time.Hour), time.Minute, "a", Participant{ID: "a", Name: "Old name", Clicks: 3}, Participant{ID: "b"}),
+		played("m2", now, 3*time.Minute, "a", Participant{ID: "a", Name: "Ada", Clicks: 5}, Participant{ID: "b"}),
+		played("m3", now.Add(-2*time.Minute), 2*time.Minute, "b", Participant{ID: "a"}, Participant{ID: "b", Clicks: 2, Penalty: time.Minute},
+			Participant{ID: "bot-1", Bot: "random"}),
+		solo,
+		elsewhere,
+	)
+
+	tests := []struct {
+		name  string
+		query LeaderboardQuery
+		want  []string // player IDs in rank order
+	}{
+		{"most wins", LeaderboardQuery{Metric: MetricMostWins}, []string{"a", "b"}},
+		{"fastest win with penalty", LeaderboardQuery{Metric: MetricFastestWin, TargetArticle: "Pizza"}, []string{"a", "b"}},
+		{"fewest clicks", LeaderboardQuery{Metric: MetricFewestClick}, []string{"b", "a"}},
+		{"time window", LeaderboardQuery{Metric: MetricFastestWin, Since: now.Add(-time.Hour)}, []string{"b", "a"}},
+		{"target article", LeaderboardQuery{Metric: MetricMostWins, TargetArticle: "Pasta"}, []string{"b"}},
+		{"win rate", LeaderboardQuery{Metric: MetricWinRate, MinPlayed: 4}, []string{"a", "b"}},
+		{"win rate minimum", LeaderboardQuery{Metric: MetricWinRate, MinPlayed: 5}, []string{}},
+		{"single player", LeaderboardQuery{Metric: MetricMostWins, PlayerID: "b"}, []string{"b"}},
+		{"page", LeaderboardQuery{Metric: MetricMostWins, Offset: 1, Limit: 1}, []string{"b"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entries, err := s.Leaderboard(tt.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := make([]string, 0, len(entries))
+			for _, e := range entries {
+				got = append(got, e.PlayerID)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("got %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+
+	entries, _ := s.Leaderboard(LeaderboardQuery{Metric: MetricMostWins, PlayerID: "a"})
+	want := LeaderboardEntry{Rank: 1, PlayerID: "a", Name: "Ada", Played: 4, Wins: 2, WinRate: 0.5, BestDuration: time.Minute, FewestClicks: 3}
+	if entries[0] != want {
+		t.Fatalf("got %+v, want %+v", entries[0], want)
+	}
+}
@@ -0,0 +1,213 @@
+package matchmaking
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	defaultGroupSize = 4
+	defaultMaxWait   = 30 * time.Second
+	defaultTicketTTL = time.Minute
+	minGroupSize     = 2
+	// matchedRetention is how long a player can still look up the game they were matched into
+	matchedRetention = 10 * time.Minute
+)
+
+// ticket states
+const (
+	StateQueued  = "queued"
+	StateMatched = "matched"
+	StateNone    = "none" // the player is not queued
+)
+
+// Ticket is a player waiting in the queue
+type Ticket struct {
+	PlayerID  string    `json:"playerID"`
+	AccountID string    `json:"accountID"`
+	Name      string    `json:"name"`
+	QueuedAt  time.Time `json:"queuedAt"`
+	Client    string    `json:"-"` // IP address the player queued from
+	SeenAt    time.Time `json:"-"` // last time the player joined or polled their status
+}
+
+// Status is a player's position in the queue or the game they were matched into
+type Status struct {
+	State    string `json:"state"`
+	Position int    `json:"position,omitempty"` // 1-based position while queued
+	Queued   int    `json:"queued"`             // number of queued players
+	GameCode string `json:"gameCode,omitempty"` // game the player was matched into
+}
+
+type matched struct {
+	gameCode  string
+	matchedAt time.Time
+}
+
+// CreateFunc creates a game for a group of tickets and returns its code,
+// the first ticket of the group is the leader
+type CreateFunc func(group []Ticket) (string, error)
+
+// Queue groups waiting players into new games. Groups are formed lazily whenever the queue is polled,
+// so the queue does not need a background worker.
+type Queue struct {
+	mu        sync.Mutex
+	groupSize int
+	maxWait   time.Duration
+	ticketTTL time.Duration
+	tickets   []Ticket
+	forming   map[string]bool    // players of groups whose game is being created, keyed by player ID
+	matched   map[string]matched // keyed by player ID
+}
+
+// NewQueue creates a queue, non-positive settings fall back to the defaults.
+// Queued players that do not poll their status for ticketTTL are dropped from the queue.
+func NewQueue(groupSize int, maxWait, ticketTTL time.Duration) *Queue {
+	if groupSize < minGroupSize {
+		groupSize = defaultGroupSize
+	}
+	if maxWait <= 0 {
+		maxWait = defaultMaxWait
+	}
+	if ticketTTL <= 0 {
+		ticketTTL = defaultTicketTTL
+	}
+	return &Queue{
+		groupSize: groupSize,
+		maxWait:   maxWait,
+		ticketTTL: ticketTTL,
+		forming:   make(map[string]bool),
+		matched:   make(map[string]matched),
+	}
+}
+
+// Join adds a player to the queue, joining again keeps the original place
+func (q *Queue) Join(t Ticket) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	delete(q.matched, t.PlayerID)
+	if q.forming[t.PlayerID] {
+		return
+	}
+	t.SeenAt = t.QueuedAt
+	for i, queued := range q.tickets {
+		if queued.PlayerID == t.PlayerID {
+			q.tickets[i].SeenAt = t.SeenAt
+			return
+		}
+	}
+	q.tickets = append(q.tickets, t)
+}
+
+// Leave removes a player from the queue and reports whether they were queued.
+// A player whose game is being created is not requeued if creating it fails.
+func (q *Queue) Leave(playerID string) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	delete(q.matched, playerID)
+	if q.forming[playerID] {
+		delete(q.forming, playerID)
+		return true
+	}
+	for i, t := range q.tickets {
+		if t.PlayerID == playerID {
+			q.tickets = append(q.tickets[:i], q.tickets[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// Poll forms as many groups as possible: full groups are formed right away,
+// and a smaller group is formed once the longest waiting player waited for maxWait.
+// The games are created without holding the lock, so joining and leaving the queue never waits for them.
+// Players of a group whose game could not be created go back to the front of the queue.
+func (q *Queue) Poll(now time.Time, create CreateFunc) error {
+	groups := q.formGroups(now)
+	var firstErr error
+	for _, group := range groups {
+		code, err := create(group)
+		q.finishGroup(group, code, err, now)
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// formGroups drops expired entries and takes the groups that can be formed out of the queue
+func (q *Queue) formGroups(now time.Time) [][]Ticket {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for playerID, m := range q.matched {
+		if now.Sub(m.matchedAt) > matchedRetention {
+			delete(q.matched, playerID)
+		}
+	}
+	tickets := q.tickets[:0]
+	for _, t := range q.tickets {
+		if now.Sub(t.SeenAt) <= q.ticketTTL {
+			tickets = append(tickets, t)
+		}
+	}
+	q.tickets = tickets
+
+	groups := make([][]Ticket, 0)
+	for len(q.tickets) >= minGroupSize {
+		size := q.groupSize
+		if len(q.tickets) < size {
+			if now.Sub(q.tickets[0].QueuedAt) < q.maxWait {
+				break
+			}
+			size = len(q.tickets)
+		}
+		group := append([]Ticket{}, q.tickets[:size]...)
+		q.tickets = q.tickets[size:]
+		for _, t := range group {
+			q.forming[t.PlayerID] = true
+		}
+		groups = append(groups, group)
+	}
+	return groups
+}
+
+// finishGroup records the game created for a group, or requeues the players still waiting for it if creating it failed
+func (q *Queue) finishGroup(group []Ticket, code string, err error, now time.Time) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	requeued := make([]Ticket, 0, len(group))
+	for _, t := range group {
+		if !q.forming[t.PlayerID] {
+			// the player left while the game was being created
+			continue
+		}
+		delete(q.forming, t.PlayerID)
+		if err != nil {
+			requeued = append(requeued, t)
+			continue
+		}
+		q.matched[t.PlayerID] = matched{gameCode: code, matchedAt: now}
+	}
+	q.tickets = append(requeued, q.tickets...)
+}
+
+// Status returns the state of a player in the queue, asking for it keeps the player's ticket alive
+func (q *Queue) Status(playerID string, now time.Time) Status {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if m, ok := q.matched[playerID]; ok {
+		return Status{State: StateMatched, Queued: len(q.tickets), GameCode: m.gameCode}
+	}
+	if q.forming[playerID] {
+		// the player's game is being created
+		return Status{State: StateQueued, Queued: len(q.tickets)}
+	}
+	for i, t := range q.tickets {
+		if t.PlayerID == playerID {
+			q.tickets[i].SeenAt = now
+			return Status{State: StateQueued, Position: i + 1, Queued: len(q.tickets)}
+		}
+	}
+	return Status{State: StateNone, Queued: len(q.tickets)}
+}
@@ -0,0 +1,111 @@
+package matchmaking
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func ticket(playerID string, queuedAt time.Time) Ticket {
+	return Ticket{PlayerID: playerID, Name: playerID, QueuedAt: queuedAt}
+}
+
+func TestPollFormsFullGroups(t *testing.T) {
+	now := time.Now()
+	q := NewQueue(2, time.Minute, time.Minute)
+	for _, id := range []string{"a", "b", "c"} {
+		q.Join(ticket(id, now))
+	}
+	groups := 0
+	err := q.Poll(now, func(group []Ticket) (string, error) {
+		groups++
+		if len(group) != 2 || group[0].PlayerID != "a" || group[1].PlayerID != "b" {
+			t.Fatalf("got group %+v", group)
+		}
+		return "GAME01", nil
+	})
+	if err != nil || groups != 1 {
+		t.Fatalf("got %d groups, error %v", groups, err)
+	}
+	if s := q.Status("a", now); s.State != StateMatched || s.GameCode != "GAME01" {
+		t.Fatalf("matched player: got %+v", s)
+	}
+	if s := q.Status("c", now); s.State != StateQueued || s.Position != 1 {
+		t.Fatalf("waiting player: got %+v", s)
+	}
+}
+
+func TestPollDoesNotHoldTheLock(t *testing.T) {
+	now := time.Now()
+	q := NewQueue(2, time.Minute, time.Minute)
+	q.Join(ticket("a", now))
+	q.Join(ticket("b", now))
+	err := q.Poll(now, func(group []Ticket) (string, error) {
+		// would deadlock if Poll held the lock while creating the game
+		q.Join(ticket("c", now))
+		if s := q.Status("a", now); s.State != StateQueued || s.Position != 0 {
+			t.Errorf("player of the forming group: got %+v", s)
+		}
+		return "GAME01", nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := q.Status("c", now); s.State != StateQueued || s.Position != 1 {
+		t.Fatalf("got %+v", s)
+	}
+}
+
+func TestPollRequeuesFailedGroups(t *testing.T) {
+	now := time.Now()
+	q := NewQueue(2, time.Minute, time.Minute)
+	q.Join(ticket("a", now))
+	q.Join(ticket("b", now))
+	failure := errors.New("database down")
+	err := q.Poll(now, func(group []Ticket) (string, error) {
+		// players leaving while the game is created are not requeued
+		q.Leave("b")
+		q.Join(ticket("c", now))
+		return "", failure
+	})
+	if !errors.Is(err, failure) {
+		t.Fatalf("got error %v", err)
+	}
+	if s := q.Status("a", now); s.State != StateQueued || s.Position != 1 {
+		t.Fatalf("requeued player: got %+v", s)
+	}
+	if s := q.Status("b", now); s.State != StateNone {
+		t.Fatalf("player that left: got %+v", s)
+	}
+	if s := q.Status("c", now); s.Position != 2 {
+		t.Fatalf("player that joined meanwhile: got %+v", s)
+	}
+}
+
+func TestPollDropsExpiredTickets(t *testing.T) {
+	start := time.Now()
+	q := NewQueue(3, time.Minute, 30*time.Second)
+	q.Join(ticket("a", start))
+	q.Join(ticket("b", start))
+	// only b keeps polling its status
+	q.Status("b", start.Add(20*time.Second))
+	q.Join(ticket("c", start.Add(40*time.Second)))
+	q.Join(ticket("d", start.Add(40*time.Second)))
+	err := q.Poll(start.Add(45*time.Second), func(group []Ticket) (string, error) {
+		for _, member := range group {
+			if member.PlayerID == "a" {
+				t.Errorf("expired player was matched: %+v", group)
+			}
+		}
+		return "GAME01", nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := q.Status("a", start.Add(45*time.Second)); s.State != StateNone {
+		t.Fatalf("expired player: got %+v", s)
+	}
+	if s := q.Status("b", start.Add(45*time.Second)); s.State != StateMatched {
+		t.Fatalf("polling player: got %+v", s)
+	}
+}
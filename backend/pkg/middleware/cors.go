@@ -3,23 +3,173 @@ package middleware
 import (
 	"github.com/gin-gonic/gin"
 	"net/http"
+	"strconv"
+	"strings"
+	"wikirace/pkg/cfg"
 )
 
-func CORSMiddleware() gin.HandlerFunc {
+var (
+	defaultCORSMethods = []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"}
+	defaultCORSHeaders = []string{"Content-Type", "Authorization", "Content-Length"}
+)
+
+// wildcardOrigin allows the subdomains of a domain, e.g. "https://*.example.com"
+type wildcardOrigin struct {
+	scheme string // e.g. "https", empty allows any scheme
+	domain string // e.g. ".example.com"
+}
+
+// corsPolicy is a cfg.CORSPolicy prepared for matching requests
+type corsPolicy struct {
+	anyOrigin   bool
+	origins     map[string]bool // lowercase origins allowed exactly
+	wildcards   []wildcardOrigin
+	methods     map[string]bool
+	anyHeader   bool
+	headers     map[string]bool // lowercase
+	allowMethod string          // values of the preflight response headers
+	allowHeader string
+	maxAge      string
+	credentials bool
+}
+
+// CORSMiddleware answers preflight requests and lets the origins allowed by policy read the responses
+func CORSMiddleware(policy cfg.CORSPolicy) gin.HandlerFunc {
+	p := newCORSPolicy(policy)
 	return func(c *gin.Context) {
 		origin := c.Request.Header.Get("Origin")
-		if origin != "" {
-			c.Writer.Header().Set("Access-Control-Allow-Origin", origin) // allow all origins (NOTE: this is not safe)
+		preflight := c.Request.Method == http.MethodOptions && c.Request.Header.Get("Access-Control-Request-Method") != ""
+		if origin == "" {
+			c.Next()
+			return
+		}
+		c.Writer.Header().Add("Vary", "Origin")
+		if !p.allowsOrigin(origin) {
+			if preflight {
+				c.AbortWithStatus(http.StatusForbidden)
+				return
+			}
+			// the browser hides the response from the page, as it has no CORS headers
+			c.Next()
+			return
+		}
+		if p.anyOrigin && !p.credentials {
+			c.Writer.Header().Set("Access-Control-Allow-Origin", "*")
+		} else {
+			c.Writer.Header().Set("Access-Control-Allow-Origin", origin)
+		}
+		if p.credentials {
 			c.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
-			c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, Content-Length")
-			c.Writer.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
+		}
+		if !preflight {
+			if c.Request.Method == http.MethodOptions {
+				c.AbortWithStatus(http.StatusNoContent)
+				return
+			}
+			c.Next()
+			return
 		}
 
-		if c.Request.Method == "OPTIONS" {
-			c.AbortWithStatus(http.StatusNoContent)
+		c.Writer.Header().Add("Vary", "Access-Control-Request-Method")
+		c.Writer.Header().Add("Vary", "Access-Control-Request-Headers")
+		if !p.methods[strings.ToUpper(c.Request.Header.Get("Access-Control-Request-Method"))] ||
+			!p.allowsHeaders(c.Request.Header.Get("Access-Control-Request-Headers")) {
+			c.AbortWithStatus(http.StatusForbidden)
 			return
 		}
+		c.Writer.Header().Set("Access-Control-Allow-Methods", p.allowMethod)
+		if p.anyHeader {
+			// echo the requested headers, as "*" is not honored for requests with credentials
+			c.Writer.Header().Set("Access-Control-Allow-Headers", c.Request.Header.Get("Access-Control-Request-Headers"))
+		} else {
+			c.Writer.Header().Set("Access-Control-Allow-Headers", p.allowHeader)
+		}
+		if p.maxAge != "" {
+			c.Writer.Header().Set("Access-Control-Max-Age", p.maxAge)
+		}
+		c.AbortWithStatus(http.StatusNoContent)
+	}
+}
+
+// newCORSPolicy fills in the defaults of a policy and indexes its lists
+func newCORSPolicy(policy cfg.CORSPolicy) *corsPolicy {
+	methods := policy.AllowedMethods
+	if len(methods) == 0 {
+		methods = defaultCORSMethods
+	}
+	headers := policy.AllowedHeaders
+	if len(headers) == 0 {
+		headers = defaultCORSHeaders
+	}
+	p := &corsPolicy{
+		origins:     make(map[string]bool),
+		methods:     make(map[string]bool),
+		headers:     make(map[string]bool),
+		allowMethod: strings.Join(methods, ", "),
+		allowHeader: strings.Join(headers, ", "),
+		credentials: policy.AllowCredentials,
+	}
+	for _, origin := range policy.AllowedOrigins {
+		origin = strings.ToLower(strings.TrimSuffix(strings.TrimSpace(origin), "/"))
+		scheme, host, found := strings.Cut(origin, "://")
+		if !found {
+			scheme, host = "", origin
+		}
+		switch {
+		case origin == "*":
+			p.anyOrigin = true
+		case strings.HasPrefix(host, "*."):
+			p.wildcards = append(p.wildcards, wildcardOrigin{scheme: scheme, domain: strings.TrimPrefix(host, "*")})
+		default:
+			p.origins[origin] = true
+		}
+	}
+	for _, method := range methods {
+		p.methods[strings.ToUpper(method)] = true
+	}
+	for _, header := range headers {
+		if header == "*" {
+			p.anyHeader = true
+		}
+		p.headers[strings.ToLower(header)] = true
+	}
+	if policy.MaxAgeSeconds > 0 {
+		p.maxAge = strconv.Itoa(policy.MaxAgeSeconds)
+	}
+	return p
+}
 
-		c.Next()
+// allowsOrigin reports whether the policy allows an origin, wildcard origins match any depth of subdomains
+func (p *corsPolicy) allowsOrigin(origin string) bool {
+	if p.anyOrigin {
+		return true
+	}
+	origin = strings.ToLower(origin)
+	if p.origins[origin] {
+		return true
+	}
+	scheme, host, found := strings.Cut(origin, "://")
+	if !found {
+		return false
+	}
+	for _, w := range p.wildcards {
+		if (w.scheme == "" || w.scheme == scheme) && len(host) > len(w.domain) && strings.HasSuffix(host, w.domain) {
+			return true
+		}
+	}
+	return false
+}
+
+// allowsHeaders reports whether the policy allows every header of an Access-Control-Request-Headers value
+func (p *corsPolicy) allowsHeaders(requested string) bool {
+	if p.anyHeader {
+		return true
+	}
+	for _, header := range strings.Split(requested, ",") {
+		header = strings.ToLower(strings.TrimSpace(header))
+		if header != "" && !p.headers[header] {
+			return false
+		}
 	}
+	return true
 }
@@ -0,0 +1,139 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"wikirace/pkg/cfg"
+)
+
+// corsRequest sends a request through CORSMiddleware to a router serving POST /ping
+func corsRequest(policy cfg.CORSPolicy, method, origin, requestMethod, requestHeaders string) *httptest.ResponseRecorder {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(CORSMiddleware(policy))
+	router.POST("/ping", func(c *gin.Context) { c.String(http.StatusOK, "pong") })
+	req := httptest.NewRequest(method, "/ping", nil)
+	if origin != "" {
+		req.Header.Set("Origin", origin)
+	}
+	if requestMethod != "" {
+		req.Header.Set("Access-Control-Request-Method", requestMethod)
+	}
+	if requestHeaders != "" {
+		req.Header.Set("Access-Control-Request-Headers", requestHeaders)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestCORSMiddleware(t *testing.T) {
+	policy := cfg.CORSPolicy{
+		AllowedOrigins: []string{"https://wikirace.example", "https://*.example.com", "*.any-scheme.org"},
+		AllowedMethods: []string{"GET", "POST"},
+		AllowedHeaders: []string{"Content-Type"},
+		MaxAgeSeconds:  600,
+	}
+	credentials := policy
+	credentials.AllowCredentials = true
+	anyOrigin := cfg.CORSPolicy{AllowedOrigins: []string{"*"}}
+	anyOriginCredentials := cfg.CORSPolicy{AllowedOrigins: []string{"*"}, AllowCredentials: true}
+
+	tests := []struct {
+		name           string
+		policy         cfg.CORSPolicy
+		method         string
+		origin         string
+		requestMethod  string
+		requestHeaders string
+		status         int
+		allowOrigin    string
+		credentials    string
+		allowMethods   string
+		maxAge         string
+		vary           []string
+	}{
+		{name: "no origin", policy: policy, method: "POST", status: 200},
+		{name: "exact origin", policy: policy, method: "POST", origin: "https://wikirace.example",
+			status: 200, allowOrigin: "https://wikirace.example", vary: []string{"Origin"}},
+		{name: "exact origin is case insensitive", policy: policy, method: "POST", origin: "HTTPS://WikiRace.example",
+			status: 200, allowOrigin: "HTTPS://WikiRace.example", vary: []string{"Origin"}},
+		{name: "wildcard subdomain", policy: policy, method: "POST", origin: "https://play.example.com",
+			status: 200, allowOrigin: "https://play.example.com", vary: []string{"Origin"}},
+		{name: "wildcard nested subdomain", policy: policy, method: "POST", origin: "https://a.b.example.com",
+			status: 200, allowOrigin: "https://a.b.example.com", vary: []string{"Origin"}},
+		{name: "wildcard without scheme", policy: policy, method: "POST", origin: "http://play.any-scheme.org",
+			status: 200, allowOrigin: "http://play.any-scheme.org", vary: []string{"Origin"}},
+		{name: "wildcard excludes the domain itself", policy: policy, method: "POST", origin: "https://example.com",
+			status: 200, vary: []string{"Origin"}},
+		{name: "wildcard checks the scheme", policy: policy, method: "POST", origin: "http://play.example.com",
+			status: 200, vary: []string{"Origin"}},
+		{name: "wildcard is no suffix match", policy: policy, method: "POST", origin: "https://evilexample.com",
+			status: 200, vary: []string{"Origin"}},
+		{name: "denied origin", policy: policy, method: "POST", origin: "https://evil.example",
+			status: 200, vary: []string{"Origin"}},
+		{name: "empty policy denies every origin", policy: cfg.CORSPolicy{}, method: "POST", origin: "http://localhost:3000",
+			status: 200, vary: []string{"Origin"}},
+		{name: "preflight", policy: policy, method: "OPTIONS", origin: "https://wikirace.example", requestMethod: "POST",
+			requestHeaders: "content-type", status: 204, allowOrigin: "https://wikirace.example", allowMethods: "GET, POST",
+			maxAge: "600", vary: []string{"Origin", "Access-Control-Request-Method", "Access-Control-Request-Headers"}},
+		{name: "preflight from denied origin", policy: policy, method: "OPTIONS", origin: "https://evil.example",
+			requestMethod: "POST", status: 403, vary: []string{"Origin"}},
+		{name: "preflight with denied method", policy: policy, method: "OPTIONS", origin: "https://wikirace.example",
+			requestMethod: "DELETE", status: 403, allowOrigin: "https://wikirace.example",
+			vary: []string{"Origin", "Access-Control-Request-Method", "Access-Control-Request-Headers"}},
+		{name: "preflight with denied header", policy: policy, method: "OPTIONS", origin: "https://wikirace.example",
+			requestMethod: "POST", requestHeaders: "Content-Type, X-Secret", status: 403, allowOrigin: "https://wikirace.example",
+			vary: []string{"Origin", "Access-Control-Request-Method", "Access-Control-Request-Headers"}},
+		{name: "credentials", policy: credentials, method: "POST", origin: "https://wikirace.example",
+			status: 200, allowOrigin: "https://wikirace.example", credentials: "true", vary: []string{"Origin"}},
+		{name: "preflight with credentials", policy: credentials, method: "OPTIONS", origin: "https://play.example.com",
+			requestMethod: "GET", status: 204, allowOrigin: "https://play.example.com", credentials: "true",
+			allowMethods: "GET, POST", maxAge: "600",
+			vary: []string{"Origin", "Access-Control-Request-Method", "Access-Control-Request-Headers"}},
+		{name: "any origin", policy: anyOrigin, method: "POST", origin: "https://somewhere.example",
+			status: 200, allowOrigin: "*", vary: []string{"Origin"}},
+		{name: "any origin with credentials echoes the origin", policy: anyOriginCredentials, method: "POST",
+			origin: "https://somewhere.example", status: 200, allowOrigin: "https://somewhere.example", credentials: "true",
+			vary: []string{"Origin"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := corsRequest(tt.policy, tt.method, tt.origin, tt.requestMethod, tt.requestHeaders)
+			if w.Code != tt.status {
+				t.Errorf("status = %d, want %d", w.Code, tt.status)
+			}
+			header := w.Header()
+			if got := header.Get("Access-Control-Allow-Origin"); got != tt.allowOrigin {
+				t.Errorf("Access-Control-Allow-Origin = %q, want %q", got, tt.allowOrigin)
+			}
+			if got := header.Get("Access-Control-Allow-Credentials"); got != tt.credentials {
+				t.Errorf("Access-Control-Allow-Credentials = %q, want %q", got, tt.credentials)
+			}
+			if got := header.Get("Access-Control-Allow-Methods"); got != tt.allowMethods {
+				t.Errorf("Access-Control-Allow-Methods = %q, want %q", got, tt.allowMethods)
+			}
+			if got := header.Get("Access-Control-Max-Age"); got != tt.maxAge {
+				t.Errorf("Access-Control-Max-Age = %q, want %q", got, tt.maxAge)
+			}
+			if got := header.Values("Vary"); !equalStrings(got, tt.vary) {
+				t.Errorf("Vary = %q, want %q", got, tt.vary)
+			}
+		})
+	}
+}
+
+// equalStrings reports whether two lists hold the same strings in the same order, nil equals empty
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
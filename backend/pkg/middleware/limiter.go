@@ -0,0 +1,133 @@
+package middleware
+
+import (
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"sync"
+	"time"
+	"wikirace/pkg/cfg"
+	"wikirace/pkg/mongodb"
+)
+
+// sweepInterval is how often the MemoryLimiter forgets the buckets that filled up again
+const sweepInterval = time.Minute
+
+// bucketState is the content of a token bucket at the time it was last updated
+type bucketState struct {
+	tokens  float64
+	updated time.Time
+	bucket  cfg.Bucket
+}
+
+// MemoryLimiter is a Limiter keeping the buckets in memory, so every server limits on its own
+type MemoryLimiter struct {
+	mu        sync.Mutex
+	buckets   map[string]*bucketState
+	lastSweep time.Time
+}
+
+// NewMemoryLimiter creates an empty MemoryLimiter
+func NewMemoryLimiter() *MemoryLimiter {
+	return &MemoryLimiter{buckets: make(map[string]*bucketState), lastSweep: time.Now()}
+}
+
+func (l *MemoryLimiter) Allow(key string, bucket cfg.Bucket) (bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := time.Now()
+	if now.Sub(l.lastSweep) > sweepInterval {
+		l.sweep(now)
+	}
+	state, ok := l.buckets[key]
+	if !ok {
+		state = &bucketState{tokens: float64(bucket.Burst), updated: now}
+		l.buckets[key] = state
+	}
+	state.bucket = bucket
+	state.tokens = refill(state.tokens, now.Sub(state.updated), bucket)
+	state.updated = now
+	if state.tokens < 1 {
+		return false, nil
+	}
+	state.tokens--
+	return true, nil
+}
+
+// sweep removes the buckets that filled up again, a missing bucket is a full one
+func (l *MemoryLimiter) sweep(now time.Time) {
+	for key, state := range l.buckets {
+		if refill(state.tokens, now.Sub(state.updated), state.bucket) >= float64(state.bucket.Burst) {
+			delete(l.buckets, key)
+		}
+	}
+	l.lastSweep = now
+}
+
+// refill adds the tokens a bucket gained in elapsed, up to its burst
+func refill(tokens float64, elapsed time.Duration, bucket cfg.Bucket) float64 {
+	tokens += elapsed.Seconds() * bucket.Rate
+	if tokens > float64(bucket.Burst) {
+		tokens = float64(bucket.Burst)
+	}
+	return tokens
+}
+
+// MongoLimiter is a Limiter keeping the buckets in the ratelimits collection, so servers sharing
+// the database share their limits
+type MongoLimiter struct {
+	collection *mongo.Collection
+}
+
+// NewMongoLimiter creates a MongoLimiter using the given client and the indexes of its collection:
+// a unique index on the key of a bucket and a TTL index removing the buckets that filled up again
+func NewMongoLimiter(db *mongo.Client) (*MongoLimiter, error) {
+	l := &MongoLimiter{
+		collection: mongodb.GetCollection(db, "wikirace", "ratelimits"),
+	}
+	_, err := l.collection.Indexes().CreateMany(nil, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "key", Value: 1}}, Options: options.Index().SetUnique(true)},
+		{Keys: bson.D{{Key: "expires", Value: 1}}, Options: options.Index().SetExpireAfterSeconds(0)},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+func (l *MongoLimiter) Allow(key string, bucket cfg.Bucket) (bool, error) {
+	allowed, err := l.allow(key, bucket)
+	if mongo.IsDuplicateKeyError(err) {
+		// a concurrent request inserted the bucket first, take the token from that one
+		allowed, err = l.allow(key, bucket)
+	}
+	return allowed, err
+}
+
+// allow refills and takes a token from a bucket, inserting it if it does not exist yet
+func (l *MongoLimiter) allow(key string, bucket cfg.Bucket) (bool, error) {
+	now := time.Now()
+	burst := float64(bucket.Burst)
+	// a bucket is full again, the same as a missing one, once it refilled for burst/rate seconds
+	expires := now.Add(time.Duration(burst / bucket.Rate * float64(time.Second)))
+	// refill and take a token in a single update, so concurrent requests cannot take the same token
+	elapsed := bson.M{"$divide": bson.A{bson.M{"$subtract": bson.A{now, bson.M{"$ifNull": bson.A{"$updated", now}}}}, 1000}}
+	refilled := bson.M{"$min": bson.A{burst, bson.M{"$add": bson.A{
+		bson.M{"$ifNull": bson.A{"$tokens", burst}},
+		bson.M{"$multiply": bson.A{elapsed, bucket.Rate}},
+	}}}}
+	update := mongo.Pipeline{
+		{{Key: "$set", Value: bson.M{"tokens": refilled, "updated": now, "expires": expires}}},
+		{{Key: "$set", Value: bson.M{"allowed": bson.M{"$gte": bson.A{"$tokens", 1}}}}},
+		{{Key: "$set", Value: bson.M{"tokens": bson.M{"$cond": bson.A{"$allowed", bson.M{"$subtract": bson.A{"$tokens", 1}}, "$tokens"}}}}},
+	}
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+	var result struct {
+		Allowed bool
+	}
+	err := l.collection.FindOneAndUpdate(nil, bson.M{"key": key}, update, opts).Decode(&result)
+	if err != nil {
+		return false, err
+	}
+	return result.Allowed, nil
+}
@@ -0,0 +1,96 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"io"
+	"wikirace/pkg/cfg"
+	"wikirace/pkg/logger"
+	"wikirace/pkg/logic/controller"
+	"wikirace/pkg/stderror"
+)
+
+// Limiter keeps the token buckets of the rate limits
+type Limiter interface {
+	// Allow takes a token from the bucket stored under key, reporting false if the bucket is empty
+	Allow(key string, bucket cfg.Bucket) (bool, error)
+}
+
+// Members validates the player of a request: it reports whether playerID is in the game and joined it from clientIP
+type Members func(gameCode, playerID, clientIP string) bool
+
+// RateLimitMiddleware rejects requests with stderror.ErrServerBusy once the client IP or the player
+// used up the bucket of the route. Routes without an entry in routes use defaultLimit.
+// Per-player limits only apply to players validated by members, so clients can neither escape them
+// with made up player IDs nor use up the bucket of another player.
+func RateLimitMiddleware(limiter Limiter, defaultLimit cfg.RouteLimit, routes map[string]cfg.RouteLimit, members Members) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		route := c.FullPath()
+		if route == "" || c.Request.Method == "OPTIONS" {
+			// unknown routes and preflight requests are answered without reaching a handler
+			c.Next()
+			return
+		}
+		limit, ok := routes[route]
+		if !ok {
+			limit = defaultLimit
+		}
+		if limit.PerIP.Rate > 0 && !allow(limiter, "ip|"+route+"|"+c.ClientIP(), limit.PerIP) {
+			reject(c, "rate limit of ip exceeded, ip: "+c.ClientIP()+", route: "+route)
+			return
+		}
+		if limit.PerPlayer.Rate > 0 {
+			gameCode, playerID := requestPlayer(c)
+			if gameCode != "" && playerID != "" && members(gameCode, playerID, c.ClientIP()) &&
+				!allow(limiter, "player|"+route+"|"+gameCode+"|"+playerID, limit.PerPlayer) {
+				reject(c, "rate limit of player exceeded, player id: "+playerID+", route: "+route)
+				return
+			}
+		}
+		c.Next()
+	}
+}
+
+// allow takes a token from a bucket, requests are let through if the limiter fails
+func allow(limiter Limiter, key string, bucket cfg.Bucket) bool {
+	if bucket.Burst < 1 {
+		bucket.Burst = 1
+	}
+	allowed, err := limiter.Allow(key, bucket)
+	if err != nil {
+		logger.Errorf("rate limiter failed, key: %v, error: %v", key, err)
+		return true
+	}
+	return allowed
+}
+
+// reject answers a request like the handlers answer a failed request
+func reject(c *gin.Context, reason string) {
+	controller.SendResponse(c, nil, stderror.New(stderror.ErrServerBusy, errors.New(reason)))
+	c.Abort()
+}
+
+// requestPlayer returns the gameCode and playerID of the query or of the JSON body, the body is left for the handler to bind
+func requestPlayer(c *gin.Context) (gameCode, playerID string) {
+	if playerID = c.Query("playerID"); playerID != "" {
+		return c.Query("gameCode"), playerID
+	}
+	if c.Request.Body == nil || c.ContentType() != gin.MIMEJSON {
+		return "", ""
+	}
+	body, err := io.ReadAll(c.Request.Body)
+	c.Request.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return "", ""
+	}
+	var req struct {
+		GameCode string `json:"gameCode"`
+		PlayerID string `json:"playerID"`
+	}
+	if err = json.Unmarshal(body, &req); err != nil {
+		return "", ""
+	}
+	return req.GameCode, req.PlayerID
+}
@@ -0,0 +1,85 @@
+package middleware
+
+import (
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"wikirace/pkg/cfg"
+	"wikirace/pkg/logger"
+)
+
+// limitedRouter serves POST /move behind RateLimitMiddleware, the handler echoes the bound playerID
+func limitedRouter(limit cfg.RouteLimit, members Members) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	// a no-op logger, InitGlobalLogger would write log files next to the test
+	logger.Logger = zap.NewNop().Sugar()
+	router := gin.New()
+	router.Use(RateLimitMiddleware(NewMemoryLimiter(), limit, nil, members))
+	router.POST("/move", func(c *gin.Context) {
+		var req struct {
+			PlayerID string `json:"playerID"`
+		}
+		if err := c.Bind(&req); err != nil {
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"code": 0, "playerID": req.PlayerID})
+	})
+	return router
+}
+
+// move sends a move of a player from an IP and returns the response code and the playerID the handler bound
+func move(router *gin.Engine, ip, playerID string) (int, string) {
+	body := `{"gameCode":"ABCDEF","playerID":"` + playerID + `"}`
+	req := httptest.NewRequest(http.MethodPost, "/move", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.RemoteAddr = ip + ":1234"
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	var resp struct {
+		Code     int    `json:"code"`
+		PlayerID string `json:"playerID"`
+	}
+	_ = json.Unmarshal(w.Body.Bytes(), &resp)
+	return resp.Code, resp.PlayerID
+}
+
+// joinedFrom is a Members accepting players whose ID starts with the IP they joined from
+func joinedFrom(gameCode, playerID, clientIP string) bool {
+	return gameCode == "ABCDEF" && strings.HasPrefix(playerID, clientIP+"/")
+}
+
+func TestRateLimitPerIP(t *testing.T) {
+	router := limitedRouter(cfg.RouteLimit{PerIP: cfg.Bucket{Rate: 0.001, Burst: 2}}, joinedFrom)
+	for i := 0; i < 2; i++ {
+		if code, playerID := move(router, "10.0.0.1", "10.0.0.1/p1"); code != 0 || playerID != "10.0.0.1/p1" {
+			t.Fatalf("request %d: got code %d, player %q", i, code, playerID)
+		}
+	}
+	if code, _ := move(router, "10.0.0.1", "10.0.0.1/p1"); code != 10002 {
+		t.Fatalf("over the limit: got code %d, want 10002", code)
+	}
+	if code, _ := move(router, "10.0.0.2", "10.0.0.2/p2"); code != 0 {
+		t.Fatalf("other IP: got code %d, want 0", code)
+	}
+}
+
+func TestRateLimitPerPlayer(t *testing.T) {
+	router := limitedRouter(cfg.RouteLimit{PerPlayer: cfg.Bucket{Rate: 0.001, Burst: 1}}, joinedFrom)
+	if code, _ := move(router, "10.0.0.1", "10.0.0.1/p1"); code != 0 {
+		t.Fatalf("first move: got code %d, want 0", code)
+	}
+	if code, _ := move(router, "10.0.0.1", "10.0.0.1/p1"); code != 10002 {
+		t.Fatalf("second move: got code %d, want 10002", code)
+	}
+	// another client sending the player's ID is not validated, so it cannot use up the player's bucket
+	if code, _ := move(router, "10.0.0.2", "10.0.0.1/p1"); code != 0 {
+		t.Fatalf("foreign client: got code %d, want 0", code)
+	}
+	if code, _ := move(router, "10.0.0.3", "10.0.0.3/p3"); code != 0 {
+		t.Fatalf("other player: got code %d, want 0", code)
+	}
+}
@@ -0,0 +1,132 @@
+package openai
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	defaultBaseURL = "https://api.openai.com/v1"
+	requestTimeout = 30 * time.Second
+)
+
+// Client is a minimal client for the OpenAI REST API
+type Client struct {
+	apiKey     string
+	baseURL    string
+	httpClient *http.Client
+}
+
+// New creates a client, an empty base URL uses the public OpenAI API
+func New(apiKey, baseURL string) *Client {
+	if baseURL == "" {
+		baseURL = defaultBaseURL
+	}
+	return &Client{
+		apiKey:     apiKey,
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		httpClient: &http.Client{Timeout: requestTimeout},
+	}
+}
+
+// Message is a chat message
+type Message struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type embeddingsRequest struct {
+	Model string   `json:"model"`
+	Input []string `json:"input"`
+}
+
+type embeddingsResponse struct {
+	Data []struct {
+		Index     int       `json:"index"`
+		Embedding []float64 `json:"embedding"`
+	} `json:"data"`
+}
+
+type chatRequest struct {
+	Model       string    `json:"model"`
+	Messages    []Message `json:"messages"`
+	Temperature float64   `json:"temperature"`
+}
+
+type chatResponse struct {
+	Choices []struct {
+		Message Message `json:"message"`
+	} `json:"choices"`
+}
+
+type errorResponse struct {
+	Error struct {
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// Embeddings returns the embedding of every input, in the order of the inputs
+func (c *Client) Embeddings(ctx context.Context, model string, input []string) ([][]float64, error) {
+	var resp embeddingsResponse
+	if err := c.post(ctx, "/embeddings", embeddingsRequest{Model: model, Input: input}, &resp); err != nil {
+		return nil, err
+	}
+	embeddings := make([][]float64, len(input))
+	for _, d := range resp.Data {
+		if d.Index < 0 || d.Index >= len(input) {
+			return nil, fmt.Errorf("openai: embedding index %d out of range", d.Index)
+		}
+		embeddings[d.Index] = d.Embedding
+	}
+	for i, e := range embeddings {
+		if e == nil {
+			return nil, fmt.Errorf("openai: missing embedding for input %d", i)
+		}
+	}
+	return embeddings, nil
+}
+
+// ChatCompletion returns the content of the first choice of a chat completion
+func (c *Client) ChatCompletion(ctx context.Context, model string, messages []Message, temperature float64) (string, error) {
+	var resp chatResponse
+	if err := c.post(ctx, "/chat/completions", chatRequest{Model: model, Messages: messages, Temperature: temperature}, &resp); err != nil {
+		return "", err
+	}
+	if len(resp.Choices) == 0 {
+		return "", errors.New("openai: no choices returned")
+	}
+	return resp.Choices[0].Message.Content, nil
+}
+
+// post sends a JSON request and decodes the JSON response
+func (c *Client) post(ctx context.Context, path string, body, out interface{}) error {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+path, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+c.apiKey)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		var apiErr errorResponse
+		_ = json.NewDecoder(resp.Body).Decode(&apiErr)
+		return fmt.Errorf("openai: %s returned %d: %s", path, resp.StatusCode, apiErr.Error.Message)
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
@@ -0,0 +1,63 @@
+package pool
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+	"strings"
+	"wikirace/pkg/stderror"
+)
+
+const (
+	FormatText     = "text"     // one title per line, lines starting with # are comments
+	FormatCSV      = "csv"      // titles in the first column, an optional header row starts with "title"
+	FormatCategory = "category" // the articles of a wiki category, see wiki.Service.CategoryMembers
+)
+
+// ParseList reads the titles of a text or CSV list
+func ParseList(format, content string) ([]string, error) {
+	switch format {
+	case "", FormatText:
+		return parseText(content), nil
+	case FormatCSV:
+		return parseCSV(content)
+	default:
+		return nil, stderror.New(stderror.ErrValidation, errors.New("unknown list format: "+format))
+	}
+}
+
+func parseText(content string) []string {
+	titles := make([]string, 0)
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		titles = append(titles, line)
+	}
+	return titles
+}
+
+func parseCSV(content string) ([]string, error) {
+	reader := csv.NewReader(strings.NewReader(content))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	reader.Comment = '#'
+	titles := make([]string, 0)
+	for first := true; ; first = false {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return titles, nil
+		}
+		if err != nil {
+			return nil, stderror.New(stderror.ErrValidation, err)
+		}
+		title := strings.TrimSpace(record[0])
+		if first && strings.EqualFold(title, "title") {
+			continue
+		}
+		if title != "" {
+			titles = append(titles, title)
+		}
+	}
+}
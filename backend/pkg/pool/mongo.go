@@ -0,0 +1,90 @@
+package pool
+
+import (
+	"context"
+	"errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"wikirace/pkg/logger"
+	"wikirace/pkg/mongodb"
+	"wikirace/pkg/stderror"
+)
+
+// MongoStore is a Store backed by the pools collection
+type MongoStore struct {
+	collection *mongo.Collection
+}
+
+// NewMongoStore creates a MongoStore using the given client
+func NewMongoStore(db *mongo.Client) *MongoStore {
+	return &MongoStore{
+		collection: mongodb.GetCollection(db, "wikirace", "pools"),
+	}
+}
+
+func (s *MongoStore) Create(p *Pool) error {
+	_, err := s.collection.InsertOne(nil, p)
+	return err
+}
+
+func (s *MongoStore) Get(id string) (*Pool, error) {
+	filter := bson.M{"id": id}
+	p := Pool{}
+	err := s.collection.FindOne(nil, filter).Decode(&p)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			logger.Debugf("pool not found: %v", id)
+			return nil, stderror.New(stderror.ErrPoolNotFound, errors.New("pool not found, id: "+id))
+		}
+		return nil, err
+	}
+	return &p, nil
+}
+
+func (s *MongoStore) List(language string, offset, limit int) ([]Pool, error) {
+	filter := bson.M{}
+	if language != "" {
+		filter["language"] = language
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "updatedat", Value: -1}})
+	if offset > 0 {
+		opts.SetSkip(int64(offset))
+	}
+	if limit > 0 {
+		opts.SetLimit(int64(limit))
+	}
+	cursor, err := s.collection.Find(nil, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	pools := make([]Pool, 0)
+	if err = cursor.All(context.Background(), &pools); err != nil {
+		return nil, err
+	}
+	return pools, nil
+}
+
+func (s *MongoStore) Update(p *Pool) error {
+	filter := bson.M{"id": p.ID}
+	result, err := s.collection.ReplaceOne(nil, filter, p)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return stderror.New(stderror.ErrPoolNotFound, errors.New("pool not found, id: "+p.ID))
+	}
+	return nil
+}
+
+func (s *MongoStore) Delete(id string) error {
+	filter := bson.M{"id": id}
+	result, err := s.collection.DeleteOne(nil, filter)
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return stderror.New(stderror.ErrPoolNotFound, errors.New("pool not found, id: "+id))
+	}
+	return nil
+}
@@ -0,0 +1,151 @@
+package pool
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+	"unicode/utf8"
+	"wikirace/pkg/helper"
+	"wikirace/pkg/stderror"
+	"wikirace/pkg/wiki"
+)
+
+const (
+	idLength             = 12
+	maxNameLength        = 64
+	maxDescriptionLength = 512
+	minArticles          = 2
+	// MaxArticles is the largest number of articles a pool may hold
+	MaxArticles = 5000
+)
+
+// Pool is a curated list of articles that start and target articles can be drawn from, e.g. "only sports"
+type Pool struct {
+	ID          string    `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	Language    string    `json:"language"` // language of the Wikipedia the articles belong to
+	OwnerID     string    `json:"ownerID"`  // account that created the pool, only it may change the pool
+	Articles    []string  `json:"articles"`
+	CreatedAt   time.Time `json:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
+
+// Summary describes a pool without its articles, for listings
+type Summary struct {
+	ID          string    `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	Language    string    `json:"language"`
+	Size        int       `json:"size"` // number of articles
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
+
+// New validates the contents and creates a pool with a fresh ID owned by an account
+func New(name, description, language, ownerID string, articles []string) (*Pool, error) {
+	id, err := helper.GenerateRandomCode(idLength)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	p := &Pool{ID: id, Language: language, OwnerID: ownerID, CreatedAt: now}
+	if err = p.Set(name, description, articles); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// Set validates and replaces the name, description and articles of a pool.
+// Articles are normalized with the title rules of the pool's language and duplicates are dropped.
+func (p *Pool) Set(name, description string, articles []string) error {
+	name = strings.TrimSpace(name)
+	description = strings.TrimSpace(description)
+	if name == "" {
+		return stderror.New(stderror.ErrValidation, errors.New("pool name is required"))
+	}
+	if utf8.RuneCountInString(name) > maxNameLength {
+		return stderror.New(stderror.ErrValidation, errors.New("pool name is too long"))
+	}
+	if utf8.RuneCountInString(description) > maxDescriptionLength {
+		return stderror.New(stderror.ErrValidation, errors.New("pool description is too long"))
+	}
+	articles = normalize(wiki.TitleRulesFor(p.Language), articles)
+	if len(articles) < minArticles || len(articles) > MaxArticles {
+		return stderror.New(stderror.ErrValidation, fmt.Errorf("a pool needs %d to %d articles, got %d", minArticles, MaxArticles, len(articles)))
+	}
+	p.Name = name
+	p.Description = description
+	p.Articles = articles
+	p.UpdatedAt = time.Now()
+	return nil
+}
+
+// CheckOwner verifies that an account may change the pool
+func (p *Pool) CheckOwner(accountID string) error {
+	if accountID == "" || accountID != p.OwnerID {
+		return stderror.New(stderror.ErrNotPoolOwner, errors.New("account does not own the pool, id: "+p.ID))
+	}
+	return nil
+}
+
+// Summary returns the listing entry of a pool
+func (p *Pool) Summary() Summary {
+	return Summary{
+		ID:          p.ID,
+		Name:        p.Name,
+		Description: p.Description,
+		Language:    p.Language,
+		Size:        len(p.Articles),
+		UpdatedAt:   p.UpdatedAt,
+	}
+}
+
+// Contains reports whether an article is part of the pool
+func (p *Pool) Contains(title string) bool {
+	titles := wiki.TitleRulesFor(p.Language)
+	for _, article := range p.Articles {
+		if titles.Same(article, title) {
+			return true
+		}
+	}
+	return false
+}
+
+// Random returns a random article of the pool other than the excluded ones
+func (p *Pool) Random(exclude ...string) (string, bool) {
+	titles := wiki.TitleRulesFor(p.Language)
+	candidates := make([]string, 0, len(p.Articles))
+	for _, article := range p.Articles {
+		excluded := false
+		for _, e := range exclude {
+			if titles.Same(article, e) {
+				excluded = true
+				break
+			}
+		}
+		if !excluded {
+			candidates = append(candidates, article)
+		}
+	}
+	if len(candidates) == 0 {
+		return "", false
+	}
+	return candidates[rand.Intn(len(candidates))], true
+}
+
+// normalize normalizes titles and removes empty and duplicate ones, keeping the original order
+func normalize(titles wiki.TitleRules, articles []string) []string {
+	result := make([]string, 0, len(articles))
+	seen := make(map[string]bool, len(articles))
+	for _, article := range articles {
+		article = titles.Normalize(article)
+		if article == "" || seen[article] {
+			continue
+		}
+		seen[article] = true
+		result = append(result, article)
+	}
+	return result
+}
@@ -0,0 +1,35 @@
+package pool
+
+import "testing"
+
+func TestCheckOwner(t *testing.T) {
+	p, err := New("Sports", "", "en", "acc1", []string{"Football", "Tennis"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = p.CheckOwner("acc1"); err != nil {
+		t.Fatalf("owner: got %v, want nil", err)
+	}
+	for _, accountID := range []string{"acc2", ""} {
+		if err = p.CheckOwner(accountID); err == nil {
+			t.Fatalf("account %q: got nil, want an error", accountID)
+		}
+	}
+	// pools without an owner cannot be changed by anybody
+	p.OwnerID = ""
+	if err = p.CheckOwner(""); err == nil {
+		t.Fatal("pool without owner: got nil, want an error")
+	}
+}
+
+func TestContains(t *testing.T) {
+	p, err := New("Sports", "", "en", "acc1", []string{"Football", "table_tennis"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for title, want := range map[string]bool{"Football": true, "Table tennis": true, "football": true, "Chess": false} {
+		if got := p.Contains(title); got != want {
+			t.Errorf("Contains(%q) = %v, want %v", title, got, want)
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package pool
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"wikirace/pkg/stderror"
+)
+
+// Store persists article pools
+type Store interface {
+	// Create stores a new pool
+	Create(p *Pool) error
+	// Get returns a pool by its ID
+	Get(id string) (*Pool, error)
+	// List returns the pools of a language, all languages if empty, most recently updated first
+	List(language string, offset, limit int) ([]Pool, error)
+	// Update replaces a stored pool
+	Update(p *Pool) error
+	// Delete removes a pool
+	Delete(id string) error
+}
+
+// MemoryStore is an in-memory Store, used for tests and local development
+type MemoryStore struct {
+	mu    sync.RWMutex
+	pools map[string]Pool
+}
+
+// NewMemoryStore creates an empty MemoryStore
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		pools: make(map[string]Pool),
+	}
+}
+
+func (s *MemoryStore) Create(p *Pool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.pools[p.ID]; ok {
+		return errors.New("pool already exists, id: " + p.ID)
+	}
+	s.pools[p.ID] = clone(*p)
+	return nil
+}
+
+func (s *MemoryStore) Get(id string) (*Pool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	p, ok := s.pools[id]
+	if !ok {
+		return nil, stderror.New(stderror.ErrPoolNotFound, errors.New("pool not found, id: "+id))
+	}
+	p = clone(p)
+	return &p, nil
+}
+
+func (s *MemoryStore) List(language string, offset, limit int) ([]Pool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	pools := make([]Pool, 0)
+	for _, p := range s.pools {
+		if language == "" || p.Language == language {
+			pools = append(pools, clone(p))
+		}
+	}
+	sort.Slice(pools, func(i, j int) bool {
+		return pools[i].UpdatedAt.After(pools[j].UpdatedAt)
+	})
+	if offset < 0 {
+		offset = 0
+	}
+	if offset >= len(pools) {
+		return []Pool{}, nil
+	}
+	pools = pools[offset:]
+	if limit > 0 && limit < len(pools) {
+		pools = pools[:limit]
+	}
+	return pools, nil
+}
+
+func (s *MemoryStore) Update(p *Pool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.pools[p.ID]; !ok {
+		return stderror.New(stderror.ErrPoolNotFound, errors.New("pool not found, id: "+p.ID))
+	}
+	s.pools[p.ID] = clone(*p)
+	return nil
+}
+
+func (s *MemoryStore) Delete(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.pools[id]; !ok {
+		return stderror.New(stderror.ErrPoolNotFound, errors.New("pool not found, id: "+id))
+	}
+	delete(s.pools, id)
+	return nil
+}
+
+// clone copies a pool so callers cannot modify the stored one
+func clone(p Pool) Pool {
+	p.Articles = append([]string{}, p.Articles...)
+	return p
+}
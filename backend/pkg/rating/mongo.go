@@ -0,0 +1,88 @@
+package rating
+
+import (
+	"context"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"wikirace/pkg/mongodb"
+)
+
+// MongoStore is a Store backed by the ratings and rating_history collections
+type MongoStore struct {
+	ratings *mongo.Collection
+	history *mongo.Collection
+}
+
+// NewMongoStore creates a MongoStore using the given client
+func NewMongoStore(db *mongo.Client) *MongoStore {
+	return &MongoStore{
+		ratings: mongodb.GetCollection(db, "wikirace", "ratings"),
+		history: mongodb.GetCollection(db, "wikirace", "rating_history"),
+	}
+}
+
+func (s *MongoStore) Get(playerIDs []string) (map[string]Rating, error) {
+	filter := bson.M{"playerid": bson.M{"$in": playerIDs}}
+	cursor, err := s.ratings.Find(nil, filter)
+	if err != nil {
+		return nil, err
+	}
+	found := make([]Rating, 0, len(playerIDs))
+	if err = cursor.All(context.Background(), &found); err != nil {
+		return nil, err
+	}
+	ratings := make(map[string]Rating, len(playerIDs))
+	for _, id := range playerIDs {
+		ratings[id] = newRating(id)
+	}
+	for _, r := range found {
+		ratings[r.PlayerID] = r
+	}
+	return ratings, nil
+}
+
+func (s *MongoStore) Record(changes []Change) error {
+	if len(changes) == 0 {
+		return nil
+	}
+	for _, c := range changes {
+		// a single update adds the change to the stored rating, or to the initial one of a new player,
+		// so ratings updated at the same time by another round are not overwritten
+		filter := bson.M{"playerid": c.PlayerID}
+		update := mongo.Pipeline{{{Key: "$set", Value: bson.D{
+			{Key: "rating", Value: bson.M{"$add": bson.A{bson.M{"$ifNull": bson.A{"$rating", initialRating}}, c.After - c.Before}}},
+			{Key: "played", Value: bson.M{"$add": bson.A{bson.M{"$ifNull": bson.A{"$played", 0}}, 1}}},
+			{Key: "updatedat", Value: bson.M{"$max": bson.A{"$updatedat", c.Time}}},
+		}}}}
+		if _, err := s.ratings.UpdateOne(nil, filter, update, options.Update().SetUpsert(true)); err != nil {
+			return err
+		}
+	}
+	docs := make([]interface{}, 0, len(changes))
+	for _, c := range changes {
+		docs = append(docs, c)
+	}
+	_, err := s.history.InsertMany(nil, docs)
+	return err
+}
+
+func (s *MongoStore) History(playerID string, offset, limit int) ([]Change, error) {
+	filter := bson.M{"playerid": playerID}
+	opts := options.Find().SetSort(bson.D{{Key: "time", Value: -1}})
+	if offset > 0 {
+		opts.SetSkip(int64(offset))
+	}
+	if limit > 0 {
+		opts.SetLimit(int64(limit))
+	}
+	cursor, err := s.history.Find(nil, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	changes := make([]Change, 0)
+	if err = cursor.All(context.Background(), &changes); err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
@@ -0,0 +1,111 @@
+package rating
+
+import (
+	"math"
+	"time"
+	"wikirace/pkg/match"
+)
+
+const (
+	initialRating = 1500.0
+	kFactor       = 32.0
+)
+
+// Rating is a player's current skill rating
+type Rating struct {
+	PlayerID  string    `json:"playerID"`
+	Rating    float64   `json:"rating"`
+	Played    int       `json:"played"` // number of rated rounds
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// Change records how a rated round changed a player's rating
+type Change struct {
+	PlayerID  string    `json:"playerID"`
+	MatchID   string    `json:"matchID"`
+	Placement int       `json:"placement"` // 1 for the winner, 2 for everybody else
+	Before    float64   `json:"before"`
+	After     float64   `json:"after"`
+	Time      time.Time `json:"time"`
+}
+
+// newRating returns the rating of a player that has not played a rated round yet
+func newRating(playerID string) Rating {
+	return Rating{
+		PlayerID: playerID,
+		Rating:   initialRating,
+	}
+}
+
+// placement returns the finishing position of a participant,
+// the winner is the only one reaching the target so all other players share second place
+func placement(p match.Participant) int {
+	if p.IsWinner {
+		return 1
+	}
+	return 2
+}
+
+// expectedScore is the Elo probability of a player rated a beating a player rated b
+func expectedScore(a, b float64) float64 {
+	return 1 / (1 + math.Pow(10, (b-a)/400))
+}
+
+// Apply updates the ratings of all participants of a finished multiplayer round.
+// Every pair of players is treated as a head-to-head game decided by their placement,
+// and the total change is scaled by the number of opponents.
+// Bots are left out, and rounds with fewer than two human players are not rated.
+// The store adds the changes to the current ratings, so rounds rated at the same time do not
+// overwrite each other's changes.
+func Apply(store Store, m *match.Match) ([]Change, error) {
+	players := make([]match.Participant, 0, len(m.Players))
+	for _, p := range m.Players {
+		if p.Bot == "" {
+			players = append(players, p)
+		}
+	}
+	if len(players) < 2 {
+		return nil, nil
+	}
+	ids := make([]string, 0, len(players))
+	for _, p := range players {
+		ids = append(ids, p.ID)
+	}
+	current, err := store.Get(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	opponents := float64(len(players) - 1)
+	changes := make([]Change, 0, len(players))
+	for _, p := range players {
+		r := current[p.ID]
+		delta := 0.0
+		for _, o := range players {
+			if o.ID == p.ID {
+				continue
+			}
+			score := 0.5
+			if placement(p) < placement(o) {
+				score = 1
+			} else if placement(p) > placement(o) {
+				score = 0
+			}
+			delta += score - expectedScore(r.Rating, current[o.ID].Rating)
+		}
+		after := r.Rating + kFactor*delta/opponents
+		changes = append(changes, Change{
+			PlayerID:  p.ID,
+			MatchID:   m.ID,
+			Placement: placement(p),
+			Before:    r.Rating,
+			After:     after,
+			Time:      m.EndTime,
+		})
+	}
+
+	if err = store.Record(changes); err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
@@ -0,0 +1,105 @@
+package rating
+
+import (
+	"math"
+	"testing"
+	"time"
+	"wikirace/pkg/match"
+)
+
+func TestExpectedScore(t *testing.T) {
+	tests := []struct {
+		a, b float64
+		want float64
+	}{
+		{1500, 1500, 0.5},
+		{1900, 1500, 10.0 / 11},
+		{1500, 1900, 1.0 / 11},
+		{1700, 1500, 1 / (1 + math.Pow(10, -0.5))},
+	}
+	for _, tt := range tests {
+		if got := expectedScore(tt.a, tt.b); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("expectedScore(%v, %v) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestApply(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name    string
+		players []match.Participant
+		want    map[string]float64 // ratings after the round, nil if the round is not rated
+	}{
+		{
+			name:    "two players",
+			players: []match.Participant{{ID: "a", IsWinner: true}, {ID: "b"}},
+			want:    map[string]float64{"a": 1516, "b": 1484},
+		},
+		{
+			name:    "losers share second place",
+			players: []match.Participant{{ID: "a", IsWinner: true}, {ID: "b"}, {ID: "c"}},
+			want:    map[string]float64{"a": 1516, "b": 1492, "c": 1492},
+		},
+		{
+			name:    "nobody won",
+			players: []match.Participant{{ID: "a"}, {ID: "b"}},
+			want:    map[string]float64{"a": 1500, "b": 1500},
+		},
+		{
+			name:    "bots are left out",
+			players: []match.Participant{{ID: "a"}, {ID: "bot-1", Bot: "random", IsWinner: true}, {ID: "b", IsWinner: true}},
+			want:    map[string]float64{"a": 1484, "b": 1516},
+		},
+		{
+			name:    "single human",
+			players: []match.Participant{{ID: "a", IsWinner: true}, {ID: "bot-1", Bot: "random"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := NewMemoryStore()
+			changes, err := Apply(store, &match.Match{ID: "m1", Players: tt.players, EndTime: now})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(changes) != len(tt.want) {
+				t.Fatalf("got %d changes, want %d", len(changes), len(tt.want))
+			}
+			for _, c := range changes {
+				if math.Abs(c.After-tt.want[c.PlayerID]) > 1e-9 || c.Before != initialRating {
+					t.Errorf("%s: got %v -> %v, want %v -> %v", c.PlayerID, c.Before, c.After, initialRating, tt.want[c.PlayerID])
+				}
+			}
+			ratings, _ := store.Get([]string{"a", "b", "c", "bot-1"})
+			if ratings["bot-1"].Played != 0 {
+				t.Errorf("rated the bot: %+v", ratings["bot-1"])
+			}
+			for id, want := range tt.want {
+				if r := ratings[id]; math.Abs(r.Rating-want) > 1e-9 || r.Played != 1 {
+					t.Errorf("%s: got %+v, want rating %v after one round", id, r, want)
+				}
+			}
+		})
+	}
+}
+
+func TestMemoryStoreRecordAddsChanges(t *testing.T) {
+	store := NewMemoryStore()
+	first, second := time.Now(), time.Now().Add(time.Minute)
+	// both rounds were computed from the initial rating, as if they were rated at the same time
+	if err := store.Record([]Change{{PlayerID: "a", MatchID: "m2", Before: 1500, After: 1516, Time: second}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Record([]Change{{PlayerID: "a", MatchID: "m1", Before: 1500, After: 1492, Time: first}}); err != nil {
+		t.Fatal(err)
+	}
+	ratings, _ := store.Get([]string{"a"})
+	if r := ratings["a"]; r.Rating != 1508 || r.Played != 2 || !r.UpdatedAt.Equal(second) {
+		t.Fatalf("got %+v, want both changes added", r)
+	}
+	history, _ := store.History("a", 0, 0)
+	if len(history) != 2 || history[0].MatchID != "m2" {
+		t.Fatalf("got history %+v, want m2 first", history)
+	}
+}
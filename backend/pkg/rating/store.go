@@ -0,0 +1,85 @@
+package rating
+
+import (
+	"sort"
+	"sync"
+)
+
+// Store persists player ratings and their history
+type Store interface {
+	// Get returns the ratings of the given players, players without a rating get the initial one
+	Get(playerIDs []string) (map[string]Rating, error)
+	// Record adds the difference between After and Before of every change to the player's current rating,
+	// counts a rated round and stores the change in the history. Concurrent changes of a player add up.
+	Record(changes []Change) error
+	// History returns the rating changes of a player, most recent first
+	History(playerID string, offset, limit int) ([]Change, error)
+}
+
+// MemoryStore is an in-memory Store, used for tests and local development
+type MemoryStore struct {
+	mu      sync.RWMutex
+	ratings map[string]Rating
+	history map[string][]Change
+}
+
+// NewMemoryStore creates an empty MemoryStore
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		ratings: make(map[string]Rating),
+		history: make(map[string][]Change),
+	}
+}
+
+func (s *MemoryStore) Get(playerIDs []string) (map[string]Rating, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	ratings := make(map[string]Rating, len(playerIDs))
+	for _, id := range playerIDs {
+		r, ok := s.ratings[id]
+		if !ok {
+			r = newRating(id)
+		}
+		ratings[id] = r
+	}
+	return ratings, nil
+}
+
+func (s *MemoryStore) Record(changes []Change) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, c := range changes {
+		r, ok := s.ratings[c.PlayerID]
+		if !ok {
+			r = newRating(c.PlayerID)
+		}
+		r.Rating += c.After - c.Before
+		r.Played++
+		if c.Time.After(r.UpdatedAt) {
+			r.UpdatedAt = c.Time
+		}
+		s.ratings[c.PlayerID] = r
+		s.history[c.PlayerID] = append(s.history[c.PlayerID], c)
+	}
+	return nil
+}
+
+func (s *MemoryStore) History(playerID string, offset, limit int) ([]Change, error) {
+	s.mu.RLock()
+	changes := append([]Change{}, s.history[playerID]...)
+	s.mu.RUnlock()
+	sort.SliceStable(changes, func(i, j int) bool {
+		return changes[i].Time.After(changes[j].Time)
+	})
+	if offset < 0 {
+		offset = 0
+	}
+	if offset >= len(changes) {
+		return []Change{}, nil
+	}
+	changes = changes[offset:]
+	if limit > 0 && limit < len(changes) {
+		changes = changes[:limit]
+	}
+	return changes, nil
+}
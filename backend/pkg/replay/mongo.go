@@ -0,0 +1,41 @@
+package replay
+
+import (
+	"errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"wikirace/pkg/logger"
+	"wikirace/pkg/mongodb"
+	"wikirace/pkg/stderror"
+)
+
+// MongoStore is a Store backed by the replays collection
+type MongoStore struct {
+	collection *mongo.Collection
+}
+
+// NewMongoStore creates a MongoStore using the given client
+func NewMongoStore(db *mongo.Client) *MongoStore {
+	return &MongoStore{
+		collection: mongodb.GetCollection(db, "wikirace", "replays"),
+	}
+}
+
+func (s *MongoStore) Create(r *Replay) error {
+	_, err := s.collection.InsertOne(nil, r)
+	return err
+}
+
+func (s *MongoStore) Get(id string) (*Replay, error) {
+	filter := bson.M{"id": id}
+	r := Replay{}
+	err := s.collection.FindOne(nil, filter).Decode(&r)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			logger.Debugf("replay not found: %v", id)
+			return nil, stderror.New(stderror.ErrReplayNotFound, errors.New("replay not found, id: "+id))
+		}
+		return nil, err
+	}
+	return &r, nil
+}
@@ -0,0 +1,163 @@
+package replay
+
+import (
+	"errors"
+	"fmt"
+	"time"
+	"wikirace/pkg/helper"
+	"wikirace/pkg/match"
+	"wikirace/pkg/stderror"
+)
+
+const (
+	// Schema identifies replay documents
+	Schema = "wikirace.replay"
+	// Version is the current version of the replay schema, bump it whenever the document layout changes
+	Version = 1
+
+	idLength   = 12
+	maxPlayers = 64
+	maxMoves   = 1000
+)
+
+// Replay is a self-contained, shareable record of a finished round
+type Replay struct {
+	Schema        string    `json:"schema"`
+	Version       int       `json:"version"`
+	ID            string    `json:"id"`      // assigned by the server when the replay is stored
+	MatchID       string    `json:"matchID"` // archived match the replay was exported from, if any
+	StartArticle  string    `json:"startArticle"`
+	TargetArticle string    `json:"targetArticle"`
+	StartTime     time.Time `json:"startTime"`
+	DurationMs    int64     `json:"durationMs"`
+	Players       []Player  `json:"players"`
+	CreatedAt     time.Time `json:"createdAt"` // set by the server when the replay is stored
+}
+
+// Player is a participant of a replay and the moves they made
+type Player struct {
+	ID        string `json:"id"` // identifies the player within the replay, never the player's own ID
+	Name      string `json:"name"`
+	IsWinner  bool   `json:"isWinner"`
+	HintsUsed int    `json:"hintsUsed,omitempty"`
+	Moves     []Move `json:"moves"`
+}
+
+// Move is an article a player reached, relative to the start of the round
+type Move struct {
+	Article  string `json:"article"`
+	OffsetMs int64  `json:"offsetMs"`
+}
+
+// FromMatch exports an archived match as a replay. Player IDs authenticate their players, so the
+// players of the replay are numbered instead.
+func FromMatch(m *match.Match) *Replay {
+	r := &Replay{
+		Schema:        Schema,
+		Version:       Version,
+		MatchID:       m.ID,
+		StartArticle:  m.StartArticle,
+		TargetArticle: m.TargetArticle,
+		StartTime:     m.StartTime,
+		DurationMs:    m.Duration.Milliseconds(),
+		Players:       make([]Player, 0, len(m.Players)),
+	}
+	for i, p := range m.Players {
+		moves := make([]Move, 0, len(p.Paths))
+		for j, article := range p.Paths {
+			// moves recorded before timestamps were tracked keep an offset of 0
+			var offset int64
+			if j < len(p.PathTimes) {
+				offset = p.PathTimes[j].Sub(m.StartTime).Milliseconds()
+			}
+			moves = append(moves, Move{Article: article, OffsetMs: offset})
+		}
+		r.Players = append(r.Players, Player{
+			ID:        fmt.Sprintf("p%d", i+1),
+			Name:      p.Name,
+			IsWinner:  p.IsWinner,
+			HintsUsed: p.HintsUsed,
+			Moves:     moves,
+		})
+	}
+	return r
+}
+
+// Validate checks that a replay is well-formed and consistent
+func (r *Replay) Validate() error {
+	invalid := func(format string, args ...interface{}) error {
+		return stderror.New(stderror.ErrValidation, fmt.Errorf("invalid replay: "+format, args...))
+	}
+	if r.Schema != Schema {
+		return invalid("unknown schema %q", r.Schema)
+	}
+	if r.Version < 1 || r.Version > Version {
+		return invalid("unsupported version %d", r.Version)
+	}
+	if r.StartArticle == "" || r.TargetArticle == "" {
+		return invalid("start and target articles are required")
+	}
+	if r.DurationMs < 0 {
+		return invalid("negative duration")
+	}
+	if len(r.Players) == 0 || len(r.Players) > maxPlayers {
+		return invalid("expected 1 to %d players, got %d", maxPlayers, len(r.Players))
+	}
+	winners := 0
+	for _, p := range r.Players {
+		if p.ID == "" {
+			return invalid("player without id")
+		}
+		if len(p.Moves) > maxMoves {
+			return invalid("player %s has more than %d moves", p.ID, maxMoves)
+		}
+		var last int64
+		for _, move := range p.Moves {
+			if move.Article == "" {
+				return invalid("player %s has a move without an article", p.ID)
+			}
+			if move.OffsetMs < last || move.OffsetMs > r.DurationMs {
+				return invalid("player %s has moves out of order", p.ID)
+			}
+			last = move.OffsetMs
+		}
+		if p.IsWinner {
+			winners++
+			if len(p.Moves) == 0 || p.Moves[len(p.Moves)-1].Article != r.TargetArticle {
+				return invalid("winner %s did not reach the target article", p.ID)
+			}
+		}
+	}
+	if winners > 1 {
+		return invalid("more than one winner")
+	}
+	return nil
+}
+
+// prepare assigns a new ID and creation time to a replay before it is stored
+func (r *Replay) prepare() error {
+	id, err := helper.GenerateRandomCode(idLength)
+	if err != nil {
+		return err
+	}
+	r.ID = id
+	r.CreatedAt = time.Now()
+	return nil
+}
+
+// Import validates a replay and stores it under a new ID
+func Import(store Store, r *Replay) (*Replay, error) {
+	if r == nil {
+		return nil, stderror.New(stderror.ErrValidation, errors.New("invalid replay: empty document"))
+	}
+	if err := r.Validate(); err != nil {
+		return nil, err
+	}
+	if err := r.prepare(); err != nil {
+		return nil, err
+	}
+	if err := store.Create(r); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
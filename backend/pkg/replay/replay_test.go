@@ -0,0 +1,34 @@
+package replay
+
+import (
+	"testing"
+	"time"
+	"wikirace/pkg/match"
+)
+
+func TestFromMatch(t *testing.T) {
+	start := time.Now()
+	m := &match.Match{
+		ID:            "ABCDEF-1",
+		StartArticle:  "Rome",
+		TargetArticle: "Pizza",
+		StartTime:     start,
+		Duration:      10 * time.Second,
+		WinnerID:      "secret-1",
+		Players: []match.Participant{
+			{ID: "secret-1", Name: "Ada", IsWinner: true, Paths: []string{"Italy", "Pizza"},
+				PathTimes: []time.Time{start.Add(4 * time.Second), start.Add(10 * time.Second)}},
+			{ID: "secret-2", Name: "Bob", Paths: []string{"Italy"}},
+		},
+	}
+	r := FromMatch(m)
+	if r.Players[0].ID != "p1" || r.Players[1].ID != "p2" {
+		t.Fatalf("got player IDs %q and %q, want p1 and p2", r.Players[0].ID, r.Players[1].ID)
+	}
+	if r.Players[0].Moves[1].OffsetMs != 10000 || r.Players[1].Moves[0].OffsetMs != 0 {
+		t.Fatalf("got moves %+v and %+v", r.Players[0].Moves, r.Players[1].Moves)
+	}
+	if err := r.Validate(); err != nil {
+		t.Fatalf("exported replay is invalid: %v", err)
+	}
+}
@@ -0,0 +1,48 @@
+package replay
+
+import (
+	"errors"
+	"sync"
+	"wikirace/pkg/stderror"
+)
+
+// Store persists imported replays
+type Store interface {
+	// Create stores a new replay
+	Create(r *Replay) error
+	// Get returns a replay by its ID
+	Get(id string) (*Replay, error)
+}
+
+// MemoryStore is an in-memory Store, used for tests and local development
+type MemoryStore struct {
+	mu      sync.RWMutex
+	replays map[string]*Replay
+}
+
+// NewMemoryStore creates an empty MemoryStore
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		replays: make(map[string]*Replay),
+	}
+}
+
+func (s *MemoryStore) Create(r *Replay) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.replays[r.ID]; ok {
+		return errors.New("replay already exists, id: " + r.ID)
+	}
+	s.replays[r.ID] = r
+	return nil
+}
+
+func (s *MemoryStore) Get(id string) (*Replay, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	r, ok := s.replays[id]
+	if !ok {
+		return nil, stderror.New(stderror.ErrReplayNotFound, errors.New("replay not found, id: "+id))
+	}
+	return r, nil
+}
@@ -4,23 +4,178 @@ import (
 	"github.com/gin-gonic/gin"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.uber.org/zap/zapio"
+	"path/filepath"
+	"time"
+	"wikirace/pkg/account"
+	"wikirace/pkg/bot"
 	"wikirace/pkg/cfg"
+	"wikirace/pkg/daily"
+	"wikirace/pkg/embedding"
+	"wikirace/pkg/game"
+	"wikirace/pkg/hint"
 	"wikirace/pkg/logger"
 	"wikirace/pkg/logic/controller"
+	"wikirace/pkg/match"
+	"wikirace/pkg/matchmaking"
 	"wikirace/pkg/middleware"
 	"wikirace/pkg/mongodb"
+	"wikirace/pkg/openai"
+	"wikirace/pkg/pool"
+	"wikirace/pkg/rating"
+	"wikirace/pkg/replay"
+	"wikirace/pkg/wiki"
 )
 
 type Server struct {
 	Config          cfg.Config
 	router          *gin.Engine
 	MongoDB         *mongo.Client
+	matchStore      match.Store
+	ratingStore     rating.Store
+	accountStore    account.Store
+	replayStore     replay.Store
+	dailyStore      daily.Store
+	poolStore       pool.Store
+	bots            *bot.Runner
+	matchmaking     *matchmaking.Queue
+	hints           *hint.Service
+	wikis           *wiki.Wikis
 	apiV1Controller *controller.APIV1
 }
 
 func New(config cfg.Config) *Server {
 	return &Server{
 		Config: config,
+		matchmaking: matchmaking.NewQueue(
+			config.Matchmaking.GroupSize,
+			time.Duration(config.Matchmaking.MaxWaitSeconds)*time.Second,
+			time.Duration(config.Matchmaking.TicketTTLSeconds)*time.Second,
+		),
+	}
+}
+
+// newEmbeddingCache creates the configured embedder behind a cache, or returns nil if embeddings are disabled
+func newEmbeddingCache(config cfg.Config, db *mongo.Client) *embedding.Cache {
+	var store embedding.Store = embedding.NewMongoStore(db)
+	if config.Embeddings.Cache == "memory" {
+		store = embedding.NewMemoryStore()
+	}
+	provider := config.Embeddings.Provider
+	if provider == "" && config.OpenAI.APIKey != "" {
+		provider = "openai"
+	}
+	switch provider {
+	case "openai":
+		client := openai.New(config.OpenAI.APIKey, config.OpenAI.BaseURL)
+		model := config.OpenAI.EmbeddingModel
+		return embedding.NewCache(hint.NewOpenAIEmbedder(client, model), model, store)
+	case "fake":
+		fake := embedding.NewFakeEmbedder(config.Embeddings.Dimensions)
+		return embedding.NewCache(fake, fake.Model(), store)
+	case "":
+		return nil
+	default:
+		logger.Fatalf("Unknown embedding provider: %s", provider)
+		return nil
+	}
+}
+
+// newHintService registers the offline hint provider, the embedding provider if embeddings are enabled
+// and, if an API key is configured, the LLM provider
+func newHintService(config cfg.Config, embeddings *embedding.Cache) *hint.Service {
+	providers := []hint.Provider{hint.NewLexicalProvider()}
+	if embeddings != nil {
+		providers = append(providers, hint.NewEmbeddingProvider(embeddings))
+	}
+	if config.OpenAI.APIKey != "" {
+		client := openai.New(config.OpenAI.APIKey, config.OpenAI.BaseURL)
+		providers = append(providers, hint.NewLLMProvider(hint.NewOpenAICompleter(client, config.OpenAI.ChatModel, 0.5)))
+	}
+	defaultProvider := config.Hints.Provider
+	if defaultProvider == "" {
+		defaultProvider = hint.ProviderLexical
+	}
+	return hint.NewService(defaultProvider, providers...)
+}
+
+// newWikis creates a wiki service with its own caches for every configured language
+func newWikis(config cfg.Config, db *mongo.Client) *wiki.Wikis {
+	languages := config.Wiki.Languages
+	if len(languages) == 0 {
+		languages = []string{wiki.DefaultLanguage}
+	}
+	defaultLanguage := config.Wiki.DefaultLanguage
+	if defaultLanguage == "" {
+		defaultLanguage = languages[0]
+	}
+	services := make(map[string]*wiki.Service, len(languages))
+	for _, language := range languages {
+		services[language] = newWikiService(config, db, language)
+	}
+	if _, ok := services[defaultLanguage]; !ok {
+		logger.Fatalf("Default wiki language %s is not one of the configured languages", defaultLanguage)
+	}
+	return wiki.NewWikis(defaultLanguage, services)
+}
+
+// newWikiService creates the configured article origin of a language behind the article caches
+func newWikiService(config cfg.Config, db *mongo.Client, language string) *wiki.Service {
+	var origin wiki.Origin
+	var resolver wiki.Resolver
+	switch config.Wiki.Origin {
+	case "", "wikipedia":
+		httpOrigin := wiki.NewHTTPOrigin(wiki.HostFor(language))
+		origin, resolver = httpOrigin, httpOrigin
+	case "fixtures":
+		fixtureOrigin := wiki.NewFixtureOrigin(filepath.Join(config.Wiki.FixturesDir, language))
+		origin, resolver = fixtureOrigin, fixtureOrigin
+	default:
+		logger.Fatalf("Unknown wiki origin: %s", config.Wiki.Origin)
+	}
+	var store wiki.Store
+	if config.Wiki.PersistentCache {
+		store = wiki.NewMongoStore(db)
+	}
+	ttl := time.Duration(config.Wiki.CacheTTLHours) * time.Hour
+	rules := wiki.Rules{
+		RemoveClasses:  config.Wiki.Sanitize.RemoveClasses,
+		RemoveSections: config.Wiki.Sanitize.RemoveSections,
+		Namespaces:     config.Wiki.Sanitize.Namespaces,
+		UnlinkSuffixes: config.Wiki.Sanitize.UnlinkSuffixes,
+	}.WithDefaults()
+	// copy the shared lists before adding the names of the language
+	rules.RemoveSections = append(append([]string{}, rules.RemoveSections...), config.Wiki.Sanitize.LocalSections[language]...)
+	rules.Namespaces = append(append([]string{}, rules.Namespaces...), config.Wiki.Sanitize.LocalNamespaces[language]...)
+	// redirects rarely change, so resolved titles are cached for the lifetime of the server
+	resolver = wiki.NewCachedResolver(resolver, config.Wiki.CacheSize)
+	return wiki.NewService(language, origin, resolver, store, config.Wiki.CacheSize, ttl, rules)
+}
+
+// newLimiter creates the configured backend of the rate limits
+func newLimiter(config cfg.Config, db *mongo.Client) middleware.Limiter {
+	switch config.RateLimit.Backend {
+	case "", "memory":
+		return middleware.NewMemoryLimiter()
+	case "mongo":
+		limiter, err := middleware.NewMongoLimiter(db)
+		if err != nil {
+			logger.Fatalf("Failed to create the rate limit indexes: %v", err)
+		}
+		return limiter
+	default:
+		logger.Fatalf("Unknown rate limit backend: %s", config.RateLimit.Backend)
+		return nil
+	}
+}
+
+// gameMembers validates the players of rate limited requests against their game
+func gameMembers(db *mongo.Client) middleware.Members {
+	return func(gameCode, playerID, clientIP string) bool {
+		g, err := game.GetGame(gameCode, db)
+		if err != nil {
+			return false
+		}
+		return g.JoinedFrom(playerID, clientIP)
 	}
 }
 
@@ -30,17 +185,36 @@ func (s *Server) Start() {
 	// initialize gin engine
 	logWriter := &zapio.Writer{Log: logger.Logger.Desugar()}
 	gin.DefaultWriter = logWriter
-	s.router = gin.Default()
-	// add middleware
-	s.router.Use(middleware.CORSMiddleware())
-	// add handlers
-	s.AddAPIHandlers()
 	// connect to MongoDB
 	mongoClient, err := mongodb.Connect(s.Config.MongoDB.URI)
 	if err != nil {
 		logger.Fatalf("Error connecting to MongoDB: %v", err)
 	}
 	s.MongoDB = mongoClient
+	s.router = gin.Default()
+	// gin trusts every proxy by default, which lets clients pick their IP with X-Forwarded-For
+	if err = s.router.SetTrustedProxies(s.Config.Server.TrustedProxies); err != nil {
+		logger.Fatalf("Invalid trusted proxies: %v", err)
+	}
+	// add middleware
+	if len(s.Config.CORS.AllowedOrigins) == 0 {
+		logger.Warnf("No CORS origins configured, browsers will block every page calling the API, see cors.allowedOrigins")
+	}
+	s.router.Use(middleware.CORSMiddleware(s.Config.CORS))
+	if s.Config.RateLimit.Enabled {
+		s.router.Use(middleware.RateLimitMiddleware(newLimiter(s.Config, mongoClient), s.Config.RateLimit.Default, s.Config.RateLimit.Routes, gameMembers(mongoClient)))
+	}
+	// add handlers
+	s.AddAPIHandlers()
+	s.matchStore = match.NewMongoStore(mongoClient)
+	s.ratingStore = rating.NewMongoStore(mongoClient)
+	s.accountStore = account.NewMongoStore(mongoClient)
+	s.replayStore = replay.NewMongoStore(mongoClient)
+	s.dailyStore = daily.NewMongoStore(mongoClient)
+	s.poolStore = pool.NewMongoStore(mongoClient)
+	s.hints = newHintService(s.Config, newEmbeddingCache(s.Config, mongoClient))
+	s.bots = bot.NewRunner(time.Duration(s.Config.Bots.DelayMs)*time.Millisecond, s.Config.Bots.MaxMoves)
+	s.wikis = newWikis(s.Config, mongoClient)
 	// start the server
 	err = s.router.Run(":" + s.Config.Server.Port)
 	if err != nil {
@@ -2,8 +2,18 @@ package server
 
 import (
 	"go.mongodb.org/mongo-driver/mongo"
+	"wikirace/pkg/account"
+	"wikirace/pkg/bot"
 	"wikirace/pkg/cfg"
+	"wikirace/pkg/daily"
+	"wikirace/pkg/hint"
 	"wikirace/pkg/logic/controller"
+	"wikirace/pkg/match"
+	"wikirace/pkg/matchmaking"
+	"wikirace/pkg/pool"
+	"wikirace/pkg/rating"
+	"wikirace/pkg/replay"
+	"wikirace/pkg/wiki"
 )
 
 func (s *Server) AddAPIHandlers() {
@@ -23,6 +33,63 @@ func (s *Server) AddAPIHandlers() {
 		v1.POST("/games/addpath", s.apiV1Controller.AddPath)
 		v1.POST("/games/reset", s.apiV1Controller.ResetGame)
 		v1.POST("/games/leave", s.apiV1Controller.LeaveGame)
+		v1.GET("/games/list", s.apiV1Controller.ListLobbies)
+
+		// matchmaking API
+		v1.POST("/matchmaking/join", s.apiV1Controller.JoinMatchmaking)
+		v1.GET("/matchmaking/status", s.apiV1Controller.GetMatchmakingStatus)
+		v1.POST("/matchmaking/leave", s.apiV1Controller.LeaveMatchmaking)
+
+		// accounts API
+		v1.POST("/accounts/create", s.apiV1Controller.CreateAccount)
+		v1.GET("/accounts/info", s.apiV1Controller.GetAccount)
+		v1.POST("/accounts/update", s.apiV1Controller.UpdateAccount)
+
+		// matches API
+		v1.GET("/matches/list", s.apiV1Controller.ListMatches)
+		v1.GET("/matches/info", s.apiV1Controller.GetMatch)
+		v1.GET("/matches/review", s.apiV1Controller.ReviewMatch)
+
+		// replays API
+		v1.GET("/replays/export", s.apiV1Controller.ExportReplay)
+		v1.POST("/replays/import", s.apiV1Controller.ImportReplay)
+		v1.GET("/replays/info", s.apiV1Controller.GetReplay)
+
+		// daily challenge API
+		v1.GET("/daily/challenge", s.apiV1Controller.GetDailyChallenge)
+		v1.POST("/daily/start", s.apiV1Controller.StartDaily)
+		v1.POST("/daily/addpath", s.apiV1Controller.DailyAddPath)
+		v1.GET("/daily/leaderboard", s.apiV1Controller.GetDailyLeaderboard)
+		v1.GET("/daily/streak", s.apiV1Controller.GetDailyStreak)
+
+		// leaderboards API
+		v1.GET("/leaderboards", s.apiV1Controller.GetLeaderboard)
+		v1.GET("/leaderboards/player", s.apiV1Controller.GetPlayerLeaderboard)
+
+		// article pools API
+		v1.POST("/pools/create", s.apiV1Controller.CreatePool)
+		v1.GET("/pools/info", s.apiV1Controller.GetPool)
+		v1.GET("/pools/list", s.apiV1Controller.ListPools)
+		v1.POST("/pools/update", s.apiV1Controller.UpdatePool)
+		v1.POST("/pools/delete", s.apiV1Controller.DeletePool)
+		v1.POST("/pools/import", s.apiV1Controller.ImportPool)
+
+		// bots API
+		v1.POST("/bots/add", s.apiV1Controller.AddBot)
+		v1.POST("/bots/remove", s.apiV1Controller.RemoveBot)
+		v1.GET("/bots/strategies", s.apiV1Controller.ListBotStrategies)
+
+		// hints API
+		v1.POST("/hints", s.apiV1Controller.GetHints)
+		v1.GET("/hints/providers", s.apiV1Controller.ListHintProviders)
+
+		// wiki API
+		v1.GET("/wiki/article", s.apiV1Controller.GetArticle)
+		v1.GET("/wiki/random", s.apiV1Controller.GetRandomArticle)
+		v1.GET("/wiki/languages", s.apiV1Controller.ListLanguages)
+
+		// ratings API
+		v1.GET("/ratings/profile", s.apiV1Controller.GetRatingProfile)
 	}
 }
 
@@ -33,3 +100,43 @@ func (s *Server) GetConfig() cfg.Config {
 func (s *Server) GetMongoDB() *mongo.Client {
 	return s.MongoDB
 }
+
+func (s *Server) GetMatchStore() match.Store {
+	return s.matchStore
+}
+
+func (s *Server) GetRatingStore() rating.Store {
+	return s.ratingStore
+}
+
+func (s *Server) GetAccountStore() account.Store {
+	return s.accountStore
+}
+
+func (s *Server) GetReplayStore() replay.Store {
+	return s.replayStore
+}
+
+func (s *Server) GetDailyStore() daily.Store {
+	return s.dailyStore
+}
+
+func (s *Server) GetPoolStore() pool.Store {
+	return s.poolStore
+}
+
+func (s *Server) GetMatchmakingQueue() *matchmaking.Queue {
+	return s.matchmaking
+}
+
+func (s *Server) GetHintService() *hint.Service {
+	return s.hints
+}
+
+func (s *Server) GetBotRunner() *bot.Runner {
+	return s.bots
+}
+
+func (s *Server) GetWikis() *wiki.Wikis {
+	return s.wikis
+}
@@ -2,17 +2,35 @@ package stderror
 
 var (
 	// common errors
-	OK                = &StdError{Code: 0, Message: "OK"}
-	ErrInternal       = &StdError{Code: 10001, Message: "Internal server error."}
-	ErrServerBusy     = &StdError{Code: 10002, Message: "Server is busy."}
-	ErrBadRequest     = &StdError{Code: 10003, Message: "Bad request."}
-	ErrBadSignature   = &StdError{Code: 10004, Message: "Bad signature."}
-	ErrBind           = &StdError{Code: 10005, Message: "Request binding error."}
-	ErrValidation     = &StdError{Code: 10006, Message: "Validation failed."}
-	ErrDatabase       = &StdError{Code: 10007, Message: "Database error."}
-	ErrAPI            = &StdError{Code: 10008, Message: "API error."}
-	ErrGameNotFound   = &StdError{Code: 10009, Message: "Game not found."}
-	ErrPlayerNotFound = &StdError{Code: 10010, Message: "Player not found."}
+	OK                 = &StdError{Code: 0, Message: "OK"}
+	ErrInternal        = &StdError{Code: 10001, Message: "Internal server error."}
+	ErrServerBusy      = &StdError{Code: 10002, Message: "Server is busy."}
+	ErrBadRequest      = &StdError{Code: 10003, Message: "Bad request."}
+	ErrBadSignature    = &StdError{Code: 10004, Message: "Bad signature."}
+	ErrBind            = &StdError{Code: 10005, Message: "Request binding error."}
+	ErrValidation      = &StdError{Code: 10006, Message: "Validation failed."}
+	ErrDatabase        = &StdError{Code: 10007, Message: "Database error."}
+	ErrAPI             = &StdError{Code: 10008, Message: "API error."}
+	ErrGameNotFound    = &StdError{Code: 10009, Message: "Game not found."}
+	ErrPlayerNotFound  = &StdError{Code: 10010, Message: "Player not found."}
+	ErrMatchNotFound   = &StdError{Code: 10011, Message: "Match not found."}
+	ErrAccountNotFound = &StdError{Code: 10012, Message: "Account not found."}
+	ErrReplayNotFound  = &StdError{Code: 10013, Message: "Replay not found."}
+	ErrGameNotFinished = &StdError{Code: 10014, Message: "Game not finished."}
+	ErrAttemptNotFound = &StdError{Code: 10015, Message: "Daily attempt not found."}
+	ErrGameNotJoinable = &StdError{Code: 10016, Message: "Game cannot be joined."}
+	ErrGameFull        = &StdError{Code: 10017, Message: "Game is full."}
+	ErrGameInProgress  = &StdError{Code: 10018, Message: "Game already started."}
+	ErrSpectator       = &StdError{Code: 10019, Message: "Spectators cannot play."}
+	ErrWrongPassword   = &StdError{Code: 10020, Message: "Wrong password."}
+	ErrTooManyAttempts = &StdError{Code: 10021, Message: "Too many attempts, try again later."}
+	ErrNoHintsLeft     = &StdError{Code: 10022, Message: "No hints left."}
+	ErrArticleNotFound = &StdError{Code: 10023, Message: "Article not found."}
+	ErrPoolNotFound    = &StdError{Code: 10024, Message: "Article pool not found."}
+	ErrNotLeader       = &StdError{Code: 10025, Message: "Only the leader of the game can do this."}
+	ErrAccountToken    = &StdError{Code: 10026, Message: "Invalid account token."}
+	ErrPlayerSpectator = &StdError{Code: 10027, Message: "Cannot play and spectate the same game."}
+	ErrNotPoolOwner    = &StdError{Code: 10028, Message: "Only the creator of the pool can do this."}
 )
 
 type StdError struct {
@@ -0,0 +1,42 @@
+package stderror
+
+import (
+	"errors"
+	"strings"
+)
+
+// FieldError describes why a request field is invalid
+type FieldError struct {
+	Field   string `json:"field"`
+	Reason  string `json:"reason"` // machine readable, e.g. "required" or "not_found"
+	Message string `json:"message"`
+}
+
+// FieldErrors lists every invalid field of a request
+type FieldErrors []FieldError
+
+func (e FieldErrors) Error() string {
+	messages := make([]string, 0, len(e))
+	for _, f := range e {
+		messages = append(messages, f.Field+": "+f.Message)
+	}
+	return strings.Join(messages, "; ")
+}
+
+// NewValidation creates an ErrValidation carrying the invalid fields, they are returned to the frontend
+func NewValidation(fields FieldErrors) *WrappedError {
+	return New(ErrValidation, fields)
+}
+
+// Fields returns the invalid fields carried by an error, or nil
+func Fields(err error) FieldErrors {
+	var wrapped *WrappedError
+	if !errors.As(err, &wrapped) {
+		return nil
+	}
+	var fields FieldErrors
+	if errors.As(wrapped.RealError, &fields) {
+		return fields
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package wiki
+
+import (
+	"container/list"
+	"sync"
+)
+
+// lru is a fixed size cache evicting the least recently used value
+type lru[V any] struct {
+	mu       sync.Mutex
+	capacity int
+	order    *list.List // front is the most recently used
+	items    map[string]*list.Element
+}
+
+type lruItem[V any] struct {
+	key   string
+	value V
+}
+
+// newLRU creates an lru holding up to capacity values, a non-positive capacity disables caching
+func newLRU[V any](capacity int) *lru[V] {
+	return &lru[V]{
+		capacity: capacity,
+		order:    list.New(),
+		items:    make(map[string]*list.Element),
+	}
+}
+
+func (c *lru[V]) get(key string) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.items[key]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	c.order.MoveToFront(e)
+	return e.Value.(*lruItem[V]).value, true
+}
+
+func (c *lru[V]) put(key string, value V) {
+	if c.capacity <= 0 {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok := c.items[key]; ok {
+		e.Value.(*lruItem[V]).value = value
+		c.order.MoveToFront(e)
+		return
+	}
+	c.items[key] = c.order.PushFront(&lruItem[V]{key: key, value: value})
+	for c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.items, oldest.Value.(*lruItem[V]).key)
+	}
+}
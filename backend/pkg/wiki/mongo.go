@@ -0,0 +1,46 @@
+package wiki
+
+import (
+	"errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"wikirace/pkg/mongodb"
+)
+
+// MongoStore is a Store backed by the articles collection
+type MongoStore struct {
+	collection *mongo.Collection
+}
+
+// cachedArticle is an article with the key it is cached under
+type cachedArticle struct {
+	Key     string
+	Article Article
+}
+
+// NewMongoStore creates a MongoStore using the given client
+func NewMongoStore(db *mongo.Client) *MongoStore {
+	return &MongoStore{
+		collection: mongodb.GetCollection(db, "wikirace", "articles"),
+	}
+}
+
+func (s *MongoStore) Get(key string) (*Article, error) {
+	filter := bson.M{"key": key}
+	cached := cachedArticle{}
+	err := s.collection.FindOne(nil, filter).Decode(&cached)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &cached.Article, nil
+}
+
+func (s *MongoStore) Put(key string, a *Article) error {
+	filter := bson.M{"key": key}
+	_, err := s.collection.ReplaceOne(nil, filter, cachedArticle{Key: key, Article: *a}, options.Replace().SetUpsert(true))
+	return err
+}
@@ -0,0 +1,228 @@
+package wiki
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+	"wikirace/pkg/stderror"
+)
+
+const (
+	DefaultLanguage = "en"
+	DefaultHost     = DefaultLanguage + ".wikipedia.org"
+	userAgent       = "wikirace/1.0 (https://github.com/KevenLi8888/hackatbrown25)"
+)
+
+// HostFor returns the host of the Wikipedia in a language, e.g. de.wikipedia.org for "de"
+func HostFor(language string) string {
+	return language + ".wikipedia.org"
+}
+
+// HTTPOrigin fetches articles from the MediaWiki parse API of a wiki
+type HTTPOrigin struct {
+	host       string
+	httpClient *http.Client
+}
+
+// NewHTTPOrigin creates an HTTPOrigin for a wiki host such as en.wikipedia.org
+func NewHTTPOrigin(host string) *HTTPOrigin {
+	if host == "" {
+		host = DefaultHost
+	}
+	return &HTTPOrigin{
+		host:       host,
+		httpClient: &http.Client{Timeout: 15 * time.Second},
+	}
+}
+
+type parseResponse struct {
+	Parse struct {
+		Title string `json:"title"`
+		Text  string `json:"text"`
+	} `json:"parse"`
+	Error *struct {
+		Code string `json:"code"`
+		Info string `json:"info"`
+	} `json:"error"`
+}
+
+func (o *HTTPOrigin) Fetch(ctx context.Context, title string) (*RawArticle, error) {
+	params := url.Values{
+		"action":             {"parse"},
+		"format":             {"json"},
+		"formatversion":      {"2"},
+		"page":               {title},
+		"prop":               {"text"},
+		"redirects":          {"true"},
+		"disableeditsection": {"1"},
+	}
+	endpoint := "https://" + o.host + "/w/api.php?" + params.Encode()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", userAgent)
+
+	resp, err := o.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s returned %d", o.host, resp.StatusCode)
+	}
+	var parsed parseResponse
+	if err = json.NewDecoder(resp.Body).Decode(&parsed); err != nil {
+		return nil, err
+	}
+	if parsed.Error != nil {
+		if parsed.Error.Code == "missingtitle" || parsed.Error.Code == "invalidtitle" {
+			return nil, stderror.New(stderror.ErrArticleNotFound, errors.New("article not found, title: "+title))
+		}
+		return nil, fmt.Errorf("%s: %s", parsed.Error.Code, parsed.Error.Info)
+	}
+	return &RawArticle{Title: parsed.Parse.Title, HTML: parsed.Parse.Text}, nil
+}
+
+// FixtureOrigin serves articles from HTML files in a directory, named after the title with
+// underscores, e.g. "Roman_Empire.html" ("/" is written as "%2F"), so the game can run without network access.
+// A file containing only "#REDIRECT <title>" redirects to another article.
+type FixtureOrigin struct {
+	dir string
+}
+
+// NewFixtureOrigin creates a FixtureOrigin reading from dir
+func NewFixtureOrigin(dir string) *FixtureOrigin {
+	return &FixtureOrigin{dir: dir}
+}
+
+// maxRedirects stops redirect loops between fixtures
+const maxRedirects = 5
+
+func (o *FixtureOrigin) Fetch(_ context.Context, title string) (*RawArticle, error) {
+	for i := 0; i <= maxRedirects; i++ {
+		content, err := os.ReadFile(o.path(title))
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				return nil, stderror.New(stderror.ErrArticleNotFound, errors.New("article not found, title: "+title))
+			}
+			return nil, err
+		}
+		body := strings.TrimSpace(string(content))
+		if !strings.HasPrefix(body, "#REDIRECT ") {
+			return &RawArticle{Title: canonicalTitle(title), HTML: body}, nil
+		}
+		title = strings.TrimPrefix(body, "#REDIRECT ")
+	}
+	return nil, errors.New("too many redirects, title: " + title)
+}
+
+// Random asks the MediaWiki query API of the wiki for a random article
+func (o *HTTPOrigin) Random(ctx context.Context) (string, error) {
+	parsed, err := o.query(ctx, url.Values{
+		"list":        {"random"},
+		"rnnamespace": {"0"},
+		"rnlimit":     {"1"},
+	})
+	if err != nil {
+		return "", err
+	}
+	if len(parsed.Query.Random) == 0 {
+		return "", errors.New(o.host + " returned no random article")
+	}
+	return canonicalTitle(parsed.Query.Random[0].Title), nil
+}
+
+// Random picks a fixture that is no redirect
+func (o *FixtureOrigin) Random(_ context.Context) (string, error) {
+	files, err := os.ReadDir(o.dir)
+	if err != nil {
+		return "", err
+	}
+	var titles []string
+	for _, f := range files {
+		title, ok := titleFromFileName(f.Name())
+		if f.IsDir() || !ok || !isMainNamespace(title) {
+			continue
+		}
+		content, err := os.ReadFile(filepath.Join(o.dir, f.Name()))
+		if err != nil {
+			return "", err
+		}
+		if !strings.HasPrefix(strings.TrimSpace(string(content)), "#REDIRECT ") {
+			titles = append(titles, title)
+		}
+	}
+	if len(titles) == 0 {
+		return "", stderror.New(stderror.ErrArticleNotFound, errors.New("no fixtures in "+o.dir))
+	}
+	return titles[rand.Intn(len(titles))], nil
+}
+
+// categoryPageSize is the largest page of category members the MediaWiki API returns to anonymous clients
+const categoryPageSize = 500
+
+// CategoryMembers pages through the category members listed by the MediaWiki query API of the wiki
+func (o *HTTPOrigin) CategoryMembers(ctx context.Context, category string, limit int) ([]string, error) {
+	titles := make([]string, 0)
+	params := url.Values{
+		"list":        {"categorymembers"},
+		"cmtitle":     {"Category:" + category},
+		"cmnamespace": {"0"},
+		"cmtype":      {"page"},
+		"cmlimit":     {strconv.Itoa(categoryPageSize)},
+	}
+	for {
+		parsed, err := o.query(ctx, params)
+		if err != nil {
+			return nil, err
+		}
+		for _, member := range parsed.Query.CategoryMembers {
+			if len(titles) == limit {
+				return titles, nil
+			}
+			titles = append(titles, canonicalTitle(member.Title))
+		}
+		next := parsed.Continue["cmcontinue"]
+		if next == "" {
+			return titles, nil
+		}
+		params.Set("cmcontinue", next)
+	}
+}
+
+// CategoryMembers returns the fixtures that link to the category page, like the category links of an article
+func (o *FixtureOrigin) CategoryMembers(_ context.Context, category string, limit int) ([]string, error) {
+	files, err := os.ReadDir(o.dir)
+	if err != nil {
+		return nil, err
+	}
+	href := `href="` + hrefFor("Category:"+category) + `"`
+	titles := make([]string, 0)
+	for _, f := range files {
+		title, ok := titleFromFileName(f.Name())
+		if f.IsDir() || !ok || !isMainNamespace(title) {
+			continue
+		}
+		content, err := os.ReadFile(filepath.Join(o.dir, f.Name()))
+		if err != nil {
+			return nil, err
+		}
+		if strings.Contains(string(content), href) {
+			if len(titles) == limit {
+				break
+			}
+			titles = append(titles, title)
+		}
+	}
+	return titles, nil
+}
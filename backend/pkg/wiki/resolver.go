@@ -0,0 +1,216 @@
+package wiki
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Resolver follows redirects to the canonical title of an article and looks up what kind of page it is
+type Resolver interface {
+	// Resolve returns the title a title redirects to, or the title itself if it is no redirect.
+	// Missing articles are no error, their title is returned unchanged.
+	Resolve(ctx context.Context, title string) (string, error)
+	// Lookup describes the page a title refers to, after following redirects
+	Lookup(ctx context.Context, title string) (*Page, error)
+}
+
+// Page describes the page a title refers to
+type Page struct {
+	Title          string // canonical title, after following redirects
+	Exists         bool
+	MainNamespace  bool // the page is an article, not a category, file, template, ... page
+	Disambiguation bool
+	Linked         bool // at least one article links to the page, so it can be reached in the game
+}
+
+// cachedResolver remembers the titles resolved by another resolver
+type cachedResolver struct {
+	resolver Resolver
+	cache    *lru[string]
+}
+
+// NewCachedResolver caches up to size titles resolved by resolver in memory
+func NewCachedResolver(resolver Resolver, size int) Resolver {
+	return &cachedResolver{resolver: resolver, cache: newLRU[string](size)}
+}
+
+func (r *cachedResolver) Resolve(ctx context.Context, title string) (string, error) {
+	if resolved, ok := r.cache.get(title); ok {
+		return resolved, nil
+	}
+	resolved, err := r.resolver.Resolve(ctx, title)
+	if err != nil {
+		return "", err
+	}
+	r.cache.put(title, resolved)
+	return resolved, nil
+}
+
+func (r *cachedResolver) Lookup(ctx context.Context, title string) (*Page, error) {
+	// lookups validate game settings, so they are rare and should see the current state of the wiki
+	return r.resolver.Lookup(ctx, title)
+}
+
+type queryResponse struct {
+	Query struct {
+		Pages []struct {
+			Title     string            `json:"title"`
+			Namespace int               `json:"ns"`
+			Missing   bool              `json:"missing"`
+			Invalid   bool              `json:"invalid"`
+			PageProps map[string]string `json:"pageprops"`
+			LinksHere []struct {
+				Title string `json:"title"`
+			} `json:"linkshere"`
+		} `json:"pages"`
+		Random []struct {
+			Title string `json:"title"`
+		} `json:"random"`
+		CategoryMembers []struct {
+			Title string `json:"title"`
+		} `json:"categorymembers"`
+	} `json:"query"`
+	Continue map[string]string `json:"continue"`
+}
+
+// Resolve asks the MediaWiki query API of the wiki for the target of a redirect
+func (o *HTTPOrigin) Resolve(ctx context.Context, title string) (string, error) {
+	parsed, err := o.query(ctx, url.Values{"titles": {title}})
+	if err != nil {
+		return "", err
+	}
+	if len(parsed.Query.Pages) == 0 {
+		return title, nil
+	}
+	return canonicalTitle(parsed.Query.Pages[0].Title), nil
+}
+
+// Lookup asks the MediaWiki query API of the wiki about a page and whether articles link to it
+func (o *HTTPOrigin) Lookup(ctx context.Context, title string) (*Page, error) {
+	parsed, err := o.query(ctx, url.Values{
+		"titles":      {title},
+		"prop":        {"pageprops|linkshere"},
+		"ppprop":      {"disambiguation"},
+		"lhnamespace": {"0"},
+		"lhshow":      {"!redirect"},
+		"lhlimit":     {"1"},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(parsed.Query.Pages) == 0 {
+		return &Page{Title: title}, nil
+	}
+	p := parsed.Query.Pages[0]
+	_, disambiguation := p.PageProps["disambiguation"]
+	return &Page{
+		Title:          canonicalTitle(p.Title),
+		Exists:         !p.Missing && !p.Invalid,
+		MainNamespace:  p.Namespace == 0,
+		Disambiguation: disambiguation,
+		Linked:         len(p.LinksHere) > 0,
+	}, nil
+}
+
+// query calls the MediaWiki query API following redirects
+func (o *HTTPOrigin) query(ctx context.Context, params url.Values) (*queryResponse, error) {
+	params.Set("action", "query")
+	params.Set("format", "json")
+	params.Set("formatversion", "2")
+	params.Set("redirects", "1")
+	endpoint := "https://" + o.host + "/w/api.php?" + params.Encode()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", userAgent)
+
+	resp, err := o.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s returned %d", o.host, resp.StatusCode)
+	}
+	var parsed queryResponse
+	if err = json.NewDecoder(resp.Body).Decode(&parsed); err != nil {
+		return nil, err
+	}
+	return &parsed, nil
+}
+
+// Resolve follows the #REDIRECT fixtures starting at a title
+func (o *FixtureOrigin) Resolve(_ context.Context, title string) (string, error) {
+	for i := 0; i <= maxRedirects; i++ {
+		content, err := os.ReadFile(o.path(title))
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				return title, nil
+			}
+			return "", err
+		}
+		body := strings.TrimSpace(string(content))
+		if !strings.HasPrefix(body, "#REDIRECT ") {
+			return title, nil
+		}
+		title = canonicalTitle(strings.TrimPrefix(body, "#REDIRECT "))
+	}
+	return "", errors.New("too many redirects, title: " + title)
+}
+
+// Lookup describes a fixture, a page is linked if another fixture links to it
+func (o *FixtureOrigin) Lookup(ctx context.Context, title string) (*Page, error) {
+	title, err := o.Resolve(ctx, title)
+	if err != nil {
+		return nil, err
+	}
+	page := &Page{Title: title, MainNamespace: isMainNamespace(title)}
+	content, err := os.ReadFile(o.path(title))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return page, nil
+		}
+		return nil, err
+	}
+	page.Exists = true
+	page.Disambiguation = strings.Contains(string(content), "disambigbox") ||
+		strings.HasSuffix(title, "(disambiguation)")
+
+	files, err := os.ReadDir(o.dir)
+	if err != nil {
+		return nil, err
+	}
+	href := `href="` + hrefFor(title)
+	for _, f := range files {
+		if f.IsDir() || filepath.Join(o.dir, f.Name()) == o.path(title) {
+			continue
+		}
+		other, err := os.ReadFile(filepath.Join(o.dir, f.Name()))
+		if err != nil {
+			return nil, err
+		}
+		if strings.Contains(string(other), href+`"`) || strings.Contains(string(other), href+`#`) {
+			page.Linked = true
+			break
+		}
+	}
+	return page, nil
+}
+
+// isMainNamespace reports whether a title is outside the namespaces of the default rules
+func isMainNamespace(title string) bool {
+	return DefaultRules().allowsNamespace(title)
+}
+
+// path returns the fixture file of a title
+func (o *FixtureOrigin) path(title string) string {
+	return filepath.Join(o.dir, fileNameFor(canonicalTitle(title)))
+}
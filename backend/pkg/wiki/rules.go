@@ -0,0 +1,172 @@
+package wiki
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+	"sort"
+	"strings"
+)
+
+// Rules select what Sanitize removes from an article besides unsafe content,
+// so only links that are legal in the game remain
+type Rules struct {
+	RemoveClasses  []string // elements with one of these classes are removed with their content, e.g. navboxes
+	RemoveSections []string // level 2 sections with one of these titles are removed, e.g. "See also"
+	Namespaces     []string // links to titles in these namespaces (and their talk namespaces) are replaced by their text
+	UnlinkSuffixes []string // links to titles ending with one of these suffixes are replaced by their text
+}
+
+// DefaultRules returns the rules used when none are configured
+func DefaultRules() Rules {
+	return Rules{
+		RemoveClasses: []string{
+			"navbox", "navbox-styles", "vertical-navbox", "sidebar", "catlinks", "portalbox", "sistersitebox",
+			"hatnote", "dablink", "rellink", "searchbox", "mw-searchform", "mw-editsection",
+		},
+		RemoveSections: []string{"See also", "External links"},
+		Namespaces: []string{
+			"Category", "File", "Image", "Media", "Template", "Help", "Wikipedia", "WP", "Special", "Portal",
+			"Talk", "User", "Draft", "Module", "MediaWiki", "TimedText", "Book",
+		},
+		UnlinkSuffixes: []string{"(disambiguation)"},
+	}
+}
+
+// WithDefaults replaces the empty lists of the rules by the default ones
+func (r Rules) WithDefaults() Rules {
+	d := DefaultRules()
+	if len(r.RemoveClasses) == 0 {
+		r.RemoveClasses = d.RemoveClasses
+	}
+	if len(r.RemoveSections) == 0 {
+		r.RemoveSections = d.RemoveSections
+	}
+	if len(r.Namespaces) == 0 {
+		r.Namespaces = d.Namespaces
+	}
+	if len(r.UnlinkSuffixes) == 0 {
+		r.UnlinkSuffixes = d.UnlinkSuffixes
+	}
+	return r
+}
+
+// Fingerprint identifies the rules and the version of Sanitize, articles sanitized differently are not served from the cache
+func (r Rules) Fingerprint() string {
+	h := sha256.New()
+	h.Write([]byte(sanitizerVersion))
+	for _, list := range [][]string{r.RemoveClasses, r.RemoveSections, r.Namespaces, r.UnlinkSuffixes} {
+		sorted := append([]string{}, list...)
+		sort.Strings(sorted)
+		h.Write([]byte(strings.Join(sorted, "\x00")))
+		h.Write([]byte{0xff})
+	}
+	return hex.EncodeToString(h.Sum(nil))[:12]
+}
+
+// removesElement reports whether an element is removed for one of its classes
+func (r Rules) removesElement(n *html.Node) bool {
+	for _, class := range strings.Fields(attr(n, "class")) {
+		for _, removed := range r.RemoveClasses {
+			if class == removed {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// allowsLink reports whether a link to a title may be followed in the game
+func (r Rules) allowsLink(title string) bool {
+	if !r.allowsNamespace(title) {
+		return false
+	}
+	lower := strings.ToLower(title)
+	for _, suffix := range r.UnlinkSuffixes {
+		if strings.HasSuffix(lower, strings.ToLower(suffix)) {
+			return false
+		}
+	}
+	return true
+}
+
+// allowsNamespace reports whether a title is outside the namespaces of the rules
+func (r Rules) allowsNamespace(title string) bool {
+	i := strings.Index(title, ":")
+	if i <= 0 {
+		return true
+	}
+	namespace := strings.TrimSuffix(strings.TrimSpace(title[:i]), " talk")
+	for _, ns := range r.Namespaces {
+		if strings.EqualFold(namespace, ns) {
+			return false
+		}
+	}
+	return true
+}
+
+// removedSection returns whether a node starts a level 2 section removed by the rules.
+// Both plain headings and headings wrapped in a div.mw-heading are recognized.
+func (r Rules) removedSection(n *html.Node) bool {
+	heading := level2Heading(n)
+	if heading == nil {
+		return false
+	}
+	title := NormalizeTitle(textContent(heading))
+	id := NormalizeTitle(attr(heading, "id"))
+	for _, section := range r.RemoveSections {
+		if strings.EqualFold(title, section) || strings.EqualFold(id, section) {
+			return true
+		}
+	}
+	return false
+}
+
+// level2Heading returns the h2 element a node is or wraps, or nil
+func level2Heading(n *html.Node) *html.Node {
+	if n.Type != html.ElementNode {
+		return nil
+	}
+	if n.DataAtom == atom.H2 {
+		return n
+	}
+	if n.DataAtom == atom.Div && strings.Contains(" "+attr(n, "class")+" ", " mw-heading2 ") {
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			if c.DataAtom == atom.H2 {
+				return c
+			}
+		}
+	}
+	return nil
+}
+
+// removeSections removes the sections selected by the rules: a matching heading and its following
+// siblings up to the next level 2 heading
+func (r Rules) removeSections(n *html.Node) {
+	removing := false
+	for c := n.FirstChild; c != nil; {
+		next := c.NextSibling
+		if level2Heading(c) != nil {
+			removing = r.removedSection(c)
+		}
+		if removing {
+			n.RemoveChild(c)
+		} else {
+			r.removeSections(c)
+		}
+		c = next
+	}
+}
+
+// textContent returns the concatenated text below a node
+func textContent(n *html.Node) string {
+	if n.Type == html.TextNode {
+		return n.Data
+	}
+	var sb strings.Builder
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		sb.WriteString(textContent(c))
+	}
+	return sb.String()
+}
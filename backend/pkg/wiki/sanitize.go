@@ -0,0 +1,263 @@
+package wiki
+
+import (
+	"bytes"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+	"strings"
+)
+
+// sanitizerVersion is part of the rules fingerprint, so articles cached by an older Sanitize are sanitized again
+const sanitizerVersion = "2"
+
+// removedTags are dropped together with their content
+var removedTags = map[atom.Atom]bool{
+	atom.Script:   true,
+	atom.Style:    true,
+	atom.Link:     true,
+	atom.Meta:     true,
+	atom.Base:     true,
+	atom.Title:    true,
+	atom.Template: true,
+	atom.Noscript: true,
+	atom.Iframe:   true,
+	atom.Frame:    true,
+	atom.Frameset: true,
+	atom.Object:   true,
+	atom.Embed:    true,
+	atom.Applet:   true,
+	atom.Svg:      true,
+	atom.Math:     true,
+	atom.Audio:    true,
+	atom.Video:    true,
+	atom.Form:     true,
+	atom.Input:    true,
+	atom.Button:   true,
+	atom.Select:   true,
+	atom.Textarea: true,
+}
+
+// allowedTags are the elements articles are made of, other elements are replaced by their content
+var allowedTags = map[atom.Atom]bool{
+	atom.A: true, atom.Abbr: true, atom.B: true, atom.Bdi: true, atom.Big: true, atom.Blockquote: true,
+	atom.Br: true, atom.Caption: true, atom.Center: true, atom.Cite: true, atom.Code: true, atom.Col: true,
+	atom.Colgroup: true, atom.Dd: true, atom.Del: true, atom.Dfn: true, atom.Div: true, atom.Dl: true,
+	atom.Dt: true, atom.Em: true, atom.Figcaption: true, atom.Figure: true, atom.H1: true, atom.H2: true,
+	atom.H3: true, atom.H4: true, atom.H5: true, atom.H6: true, atom.Hr: true, atom.I: true, atom.Img: true,
+	atom.Ins: true, atom.Kbd: true, atom.Li: true, atom.Mark: true, atom.Ol: true, atom.P: true, atom.Pre: true,
+	atom.Q: true, atom.Rp: true, atom.Rt: true, atom.Ruby: true, atom.S: true, atom.Samp: true, atom.Small: true,
+	atom.Span: true, atom.Strong: true, atom.Sub: true, atom.Sup: true, atom.Table: true, atom.Tbody: true,
+	atom.Td: true, atom.Tfoot: true, atom.Th: true, atom.Thead: true, atom.Time: true, atom.Tr: true,
+	atom.U: true, atom.Ul: true, atom.Var: true, atom.Wbr: true,
+}
+
+// allowedAttributes are kept on every allowed element
+var allowedAttributes = map[string]bool{
+	"id": true, "class": true, "title": true, "lang": true, "dir": true, "style": true, "role": true,
+	"aria-hidden": true, "aria-label": true, "alt": true, "width": true, "height": true, "decoding": true,
+	"loading": true, "colspan": true, "rowspan": true, "scope": true, "align": true, "valign": true,
+	"border": true, "cellpadding": true, "cellspacing": true, "bgcolor": true, "nowrap": true,
+	"start": true, "reversed": true, "type": true, "value": true, "datetime": true,
+}
+
+// urlAttributes are the attributes holding URLs, kept only on these elements and only with allowed schemes
+var urlAttributes = map[atom.Atom]map[string]bool{
+	atom.A:   {"href": true},
+	atom.Img: {"src": true, "srcset": true},
+}
+
+// allowedSchemes are the URL schemes links and images may use, URLs without a scheme are relative
+var allowedSchemes = map[string]bool{"http": true, "https": true}
+
+// unsafeStyles are the parts of inline styles that may load content or cover the page
+var unsafeStyles = []string{"url(", "expression", "@import", "javascript:", "behavior", "position", "\\"}
+
+// Sanitize removes scripts, other unsafe content and everything selected by the rules from article HTML
+// and rewrites its links: links to other articles point to /wiki/<title> and carry the title in
+// data-article, links leaving the article or not allowed by the rules are replaced by their text.
+// Link targets are normalized with titles. It returns the sanitized HTML and the titles of the linked articles.
+func Sanitize(raw string, rules Rules, titles TitleRules) (string, []string, error) {
+	container := &html.Node{Type: html.ElementNode, Data: "div", DataAtom: atom.Div}
+	nodes, err := html.ParseFragment(strings.NewReader(raw), container)
+	if err != nil {
+		return "", nil, err
+	}
+	for _, n := range nodes {
+		container.AppendChild(n)
+	}
+
+	rules.removeSections(container)
+	links := make([]string, 0)
+	seen := make(map[string]bool)
+	walk(container, func(n *html.Node) action {
+		if n.Type == html.CommentNode || n.Type == html.DoctypeNode || removedTags[n.DataAtom] {
+			return remove
+		}
+		if n.Type != html.ElementNode {
+			return keep
+		}
+		if rules.removesElement(n) {
+			return remove
+		}
+		if !allowedTags[n.DataAtom] || n.Namespace != "" {
+			return unwrap
+		}
+		keepSafeAttributes(n)
+		if n.DataAtom != atom.A {
+			return keep
+		}
+		href := attr(n, "href")
+		if strings.HasPrefix(href, "#") {
+			// references and footnotes within the article
+			return keep
+		}
+		title, ok := titleFromHref(href, titles)
+		if !ok || !rules.allowsLink(title) {
+			return unwrap
+		}
+		setAttr(n, "href", hrefFor(title))
+		setAttr(n, "data-article", title)
+		if !seen[title] {
+			seen[title] = true
+			links = append(links, title)
+		}
+		return keep
+	})
+
+	var buf bytes.Buffer
+	for c := container.FirstChild; c != nil; c = c.NextSibling {
+		if err = html.Render(&buf, c); err != nil {
+			return "", nil, err
+		}
+	}
+	return buf.String(), links, nil
+}
+
+// action tells walk what to do with a visited node
+type action int
+
+const (
+	keep   action = iota // keep the node and visit its children
+	remove               // remove the node and its children
+	unwrap               // visit its children and replace the node by them
+)
+
+// walk visits the nodes below n depth first and applies the returned actions
+func walk(n *html.Node, visit func(*html.Node) action) {
+	for c := n.FirstChild; c != nil; {
+		next := c.NextSibling
+		switch visit(c) {
+		case remove:
+			n.RemoveChild(c)
+		case unwrap:
+			walk(c, visit)
+			replaceByChildren(c)
+		default:
+			walk(c, visit)
+		}
+		c = next
+	}
+}
+
+// replaceByChildren replaces a node by its children
+func replaceByChildren(n *html.Node) {
+	parent := n.Parent
+	for c := n.FirstChild; c != nil; {
+		next := c.NextSibling
+		n.RemoveChild(c)
+		parent.InsertBefore(c, n)
+		c = next
+	}
+	parent.RemoveChild(n)
+}
+
+// keepSafeAttributes removes the attributes that are not allowed, and URLs and styles that are not safe
+func keepSafeAttributes(n *html.Node) {
+	attrs := n.Attr[:0]
+	for _, a := range n.Attr {
+		key := strings.ToLower(a.Key)
+		switch {
+		case a.Namespace != "":
+			continue
+		case urlAttributes[n.DataAtom][key]:
+			if (key == "srcset" && !safeSrcset(a.Val)) || (key != "srcset" && !safeURL(a.Val)) {
+				continue
+			}
+		case key == "style":
+			if !safeStyle(a.Val) {
+				continue
+			}
+		case !allowedAttributes[key]:
+			continue
+		}
+		a.Key = key
+		attrs = append(attrs, a)
+	}
+	n.Attr = attrs
+}
+
+// safeURL reports whether a URL is relative or uses an allowed scheme
+func safeURL(raw string) bool {
+	scheme := urlScheme(raw)
+	return scheme == "" || allowedSchemes[scheme]
+}
+
+// urlScheme returns the lowercase scheme of a URL as browsers read it, or an empty string for relative URLs.
+// Browsers ignore spaces and control characters around a URL and tabs and newlines within it,
+// so "java\tscript:" is a javascript: URL; all of them are dropped before the scheme is read.
+func urlScheme(raw string) string {
+	normalized := strings.Map(func(r rune) rune {
+		if r <= ' ' || r == 0x7f {
+			return -1
+		}
+		return r
+	}, raw)
+	i := strings.IndexAny(normalized, ":/?#")
+	if i <= 0 || normalized[i] != ':' {
+		return ""
+	}
+	return strings.ToLower(normalized[:i])
+}
+
+// safeSrcset reports whether every image candidate of a srcset attribute has a safe URL
+func safeSrcset(srcset string) bool {
+	for _, candidate := range strings.Split(srcset, ",") {
+		fields := strings.Fields(candidate)
+		if len(fields) > 0 && !safeURL(fields[0]) {
+			return false
+		}
+	}
+	return true
+}
+
+// safeStyle reports whether an inline style only changes the look of the element itself
+func safeStyle(style string) bool {
+	lower := strings.ToLower(style)
+	for _, unsafe := range unsafeStyles {
+		if strings.Contains(lower, unsafe) {
+			return false
+		}
+	}
+	return true
+}
+
+// attr returns the value of an attribute, or an empty string if it is not set
+func attr(n *html.Node, key string) string {
+	for _, a := range n.Attr {
+		if a.Key == key {
+			return a.Val
+		}
+	}
+	return ""
+}
+
+// setAttr sets the value of an attribute
+func setAttr(n *html.Node, key, val string) {
+	for i, a := range n.Attr {
+		if a.Key == key {
+			n.Attr[i].Val = val
+			return
+		}
+	}
+	n.Attr = append(n.Attr, html.Attribute{Key: key, Val: val})
+}
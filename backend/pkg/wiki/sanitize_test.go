@@ -0,0 +1,93 @@
+package wiki
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files of the tests")
+
+// TestSanitizeGolden sanitizes the HTML fixtures in testdata/sanitize with the default rules and compares
+// the result with the .golden file next to each fixture. Run with -update after intended changes.
+func TestSanitizeGolden(t *testing.T) {
+	fixtures, err := filepath.Glob(filepath.Join("testdata", "sanitize", "*.html"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(fixtures) == 0 {
+		t.Fatal("no fixtures found")
+	}
+	for _, fixture := range fixtures {
+		name := strings.TrimSuffix(filepath.Base(fixture), ".html")
+		t.Run(name, func(t *testing.T) {
+			raw, err := os.ReadFile(fixture)
+			if err != nil {
+				t.Fatal(err)
+			}
+			html, links, err := Sanitize(string(raw), DefaultRules(), TitleRulesFor(DefaultLanguage))
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := html + "\n<!-- links: " + strings.Join(links, " | ") + " -->\n"
+			golden := strings.TrimSuffix(fixture, ".html") + ".golden"
+			if *update {
+				if err = os.WriteFile(golden, []byte(got), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			want, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != string(want) {
+				t.Errorf("sanitized %s differs from %s:\n%s", fixture, golden, got)
+			}
+		})
+	}
+}
+
+func TestSanitizeRemovesUnsafeContent(t *testing.T) {
+	raw, err := os.ReadFile(filepath.Join("testdata", "sanitize", "unsafe.html"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	html, _, err := Sanitize(string(raw), DefaultRules(), TitleRulesFor(DefaultLanguage))
+	if err != nil {
+		t.Fatal(err)
+	}
+	lower := strings.ToLower(html)
+	for _, unsafe := range []string{"<base", "javascript", "script", "onclick", "onmouseover", "<iframe", "<svg",
+		"<form", "<input", "evil.example", "data:", "position:", "xlink", "data-mw"} {
+		if strings.Contains(lower, unsafe) {
+			t.Errorf("sanitized HTML contains %q:\n%s", unsafe, html)
+		}
+	}
+}
+
+func TestURLScheme(t *testing.T) {
+	tests := []struct {
+		url    string
+		scheme string
+	}{
+		{"https://en.wikipedia.org", "https"},
+		{"HTTP://example.org", "http"},
+		{"//upload.wikimedia.org/a.png", ""},
+		{"/wiki/Moon", ""},
+		{"#cite_note-1", ""},
+		{"Moon:Phases", "moon"},
+		{"/wiki/Moon:Phases", ""},
+		{"javascript:alert(1)", "javascript"},
+		{" \x01JavaScript:alert(1)", "javascript"},
+		{"java\tscript:alert(1)", "javascript"},
+		{"java\nscript:alert(1)", "javascript"},
+		{"data:text/html,x", "data"},
+	}
+	for _, tt := range tests {
+		if got := urlScheme(tt.url); got != tt.scheme {
+			t.Errorf("urlScheme(%q) = %q, want %q", tt.url, got, tt.scheme)
+		}
+	}
+}
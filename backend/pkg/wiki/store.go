@@ -0,0 +1,46 @@
+package wiki
+
+import (
+	"sync"
+)
+
+// Store persists sanitized articles across restarts
+type Store interface {
+	// Get returns the article cached under a key, or nil if there is none
+	Get(key string) (*Article, error)
+	// Put caches an article under a key, replacing the previous one
+	Put(key string, a *Article) error
+}
+
+// MemoryStore is an in-memory Store, used for tests and local development
+type MemoryStore struct {
+	mu       sync.RWMutex
+	articles map[string]Article
+}
+
+// NewMemoryStore creates an empty MemoryStore
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		articles: make(map[string]Article),
+	}
+}
+
+func (s *MemoryStore) Get(key string) (*Article, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	a, ok := s.articles[key]
+	if !ok {
+		return nil, nil
+	}
+	a.Links = append([]string{}, a.Links...)
+	return &a, nil
+}
+
+func (s *MemoryStore) Put(key string, a *Article) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	stored := *a
+	stored.Links = append([]string{}, a.Links...)
+	s.articles[key] = stored
+	return nil
+}
@@ -0,0 +1,113 @@
+package wiki
+
+import (
+	"net/url"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// TitleRules are the title normalization rules of a wiki
+type TitleRules struct {
+	CapitalizeFirst bool // titles are case sensitive except for the first letter, which is always upper case
+}
+
+// lowercaseWikis are the languages whose Wikipedia allows titles starting with a lower case letter
+var lowercaseWikis = map[string]bool{
+	"jbo": true,
+}
+
+// TitleRulesFor returns the title rules of the Wikipedia in a language
+func TitleRulesFor(language string) TitleRules {
+	return TitleRules{CapitalizeFirst: !lowercaseWikis[language]}
+}
+
+// Normalize turns a title, a URL-encoded title or the path of a /wiki/ link into its canonical
+// display form: URL-decoded, with spaces instead of underscores, without repeated or surrounding
+// spaces and, if the rules say so, with an upper case first letter
+func (r TitleRules) Normalize(title string) string {
+	title = strings.TrimPrefix(title, "/wiki/")
+	if strings.Contains(title, "%") {
+		if decoded, err := url.PathUnescape(title); err == nil {
+			title = decoded
+		}
+	}
+	return r.normalizeDecoded(title)
+}
+
+// normalizeDecoded normalizes a title that is known to be URL-decoded, so a literal % is kept
+func (r TitleRules) normalizeDecoded(title string) string {
+	title = strings.ReplaceAll(title, "_", " ")
+	title = strings.Join(strings.Fields(title), " ")
+	if !r.CapitalizeFirst {
+		return title
+	}
+	first, size := utf8.DecodeRuneInString(title)
+	if first == utf8.RuneError {
+		return title
+	}
+	return string(unicode.ToUpper(first)) + title[size:]
+}
+
+// Same reports whether two titles refer to the same article, without resolving redirects
+func (r TitleRules) Same(a, b string) bool {
+	return r.Normalize(a) == r.Normalize(b)
+}
+
+// NormalizeTitle normalizes a title with the rules of the English Wikipedia, see TitleRules.Normalize
+func NormalizeTitle(title string) string {
+	return TitleRulesFor("en").Normalize(title)
+}
+
+// SameTitle reports whether two titles refer to the same article of the English Wikipedia,
+// without resolving redirects
+func SameTitle(a, b string) bool {
+	return NormalizeTitle(a) == NormalizeTitle(b)
+}
+
+// canonicalTitle cleans up a title returned by a wiki, which is already canonical except for underscores
+func canonicalTitle(title string) string {
+	return TitleRules{}.normalizeDecoded(title)
+}
+
+// titleFromHref returns the title an internal /wiki/ link points to, without its section
+func titleFromHref(href string, rules TitleRules) (string, bool) {
+	if !strings.HasPrefix(href, "/wiki/") {
+		return "", false
+	}
+	path := strings.TrimPrefix(href, "/wiki/")
+	if i := strings.IndexAny(path, "#?"); i >= 0 {
+		path = path[:i]
+	}
+	title, err := url.PathUnescape(path)
+	if err != nil {
+		return "", false
+	}
+	title = rules.normalizeDecoded(title)
+	return title, title != ""
+}
+
+// unescapedInHref are the characters Wikipedia leaves unescaped in article links
+var unescapedInHref = strings.NewReplacer("%28", "(", "%29", ")", "%2F", "/", "%2C", ",", "%3B", ";")
+
+// hrefFor returns the internal link to an article, escaped the way Wikipedia escapes it
+func hrefFor(title string) string {
+	return "/wiki/" + unescapedInHref.Replace(url.PathEscape(strings.ReplaceAll(title, " ", "_")))
+}
+
+// fileNameFor returns the name of the fixture file of an article: the title with underscores,
+// only % and / are escaped so the name stays readable
+func fileNameFor(title string) string {
+	escaped := strings.NewReplacer("%", "%25", "/", "%2F").Replace(title)
+	return strings.ReplaceAll(escaped, " ", "_") + ".html"
+}
+
+// titleFromFileName reverses fileNameFor
+func titleFromFileName(name string) (string, bool) {
+	if !strings.HasSuffix(name, ".html") {
+		return "", false
+	}
+	name = strings.TrimSuffix(name, ".html")
+	title := strings.NewReplacer("%2F", "/", "%25", "%").Replace(name)
+	return canonicalTitle(title), title != ""
+}
@@ -0,0 +1,24 @@
+package wiki
+
+import "testing"
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		language string
+		title    string
+		want     string
+	}{
+		{"en", "barack_Obama", "Barack Obama"},
+		{"en", "Barack%20Obama", "Barack Obama"},
+		{"en", "  New   York ", "New York"},
+		{"en", "/wiki/Caf%C3%A9_au_lait", "Café au lait"},
+		{"en", "/wiki/foo", "Foo"},
+		{"en", "100%", "100%"},
+		{"jbo", "/wiki/lojban", "lojban"},
+	}
+	for _, tt := range tests {
+		if got := TitleRulesFor(tt.language).Normalize(tt.title); got != tt.want {
+			t.Errorf("Normalize(%q) in %s = %q, want %q", tt.title, tt.language, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,194 @@
+package wiki
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+	"wikirace/pkg/logger"
+	"wikirace/pkg/stderror"
+)
+
+// Article is a sanitized article ready to be shown to players
+type Article struct {
+	Title     string    `json:"title"`     // canonical title, after following redirects
+	HTML      string    `json:"html"`      // sanitized body, internal links point to /wiki/<title>
+	Links     []string  `json:"links"`     // titles of the articles the body links to, in order of appearance
+	FetchedAt time.Time `json:"fetchedAt"` // when the article was fetched from the origin
+}
+
+// RawArticle is an article as returned by an origin
+type RawArticle struct {
+	Title string
+	HTML  string
+}
+
+// Origin fetches the parsed HTML of articles
+type Origin interface {
+	// Fetch returns an article by title, following redirects,
+	// an error wrapping stderror.ErrArticleNotFound is returned for missing articles
+	Fetch(ctx context.Context, title string) (*RawArticle, error)
+	// Random returns the title of a random article of the main namespace
+	Random(ctx context.Context) (string, error)
+	// CategoryMembers returns up to limit titles of the articles in a category, given without namespace
+	CategoryMembers(ctx context.Context, category string, limit int) ([]string, error)
+}
+
+// Service serves sanitized articles of the Wikipedia in one language from an in-memory LRU cache,
+// an optional persistent store and finally the origin
+type Service struct {
+	language string
+	titles   TitleRules
+	origin   Origin
+	resolver Resolver
+	store    Store // nil disables the persistent cache
+	cache    *lru[*Article]
+	ttl      time.Duration // cached articles older than ttl are fetched again, zero means forever
+	rules    Rules
+	prefix   string // language and fingerprint of the rules, see cacheKey
+}
+
+// NewService creates a Service for the Wikipedia in language keeping up to cacheSize articles in memory
+// and sanitizing them with rules, titles are canonicalized with resolver
+func NewService(language string, origin Origin, resolver Resolver, store Store, cacheSize int, ttl time.Duration, rules Rules) *Service {
+	return &Service{
+		language: language,
+		titles:   TitleRulesFor(language),
+		origin:   origin,
+		resolver: resolver,
+		store:    store,
+		cache:    newLRU[*Article](cacheSize),
+		ttl:      ttl,
+		rules:    rules,
+		prefix:   language + "|" + rules.Fingerprint() + "|",
+	}
+}
+
+// Language returns the language code of the wiki, e.g. "en"
+func (s *Service) Language() string {
+	return s.language
+}
+
+// Titles returns the title normalization rules of the wiki
+func (s *Service) Titles() TitleRules {
+	return s.titles
+}
+
+// cacheKey returns the key an article is cached under,
+// articles of other languages or sanitized with other rules use other keys
+func (s *Service) cacheKey(title string) string {
+	return s.prefix + title
+}
+
+// Article returns the sanitized article with the given title
+func (s *Service) Article(ctx context.Context, title string) (*Article, error) {
+	title = s.titles.Normalize(title)
+	if title == "" {
+		return nil, stderror.New(stderror.ErrBadRequest, errors.New("title is required"))
+	}
+	now := time.Now()
+	key := s.cacheKey(title)
+	if a, ok := s.cache.get(key); ok && s.fresh(a, now) {
+		return a, nil
+	}
+	if s.store != nil {
+		a, err := s.store.Get(key)
+		if err != nil {
+			// the persistent cache is an optimization, fall back to the origin
+			logger.Warnf("error reading cached article %q: %v", title, err)
+		} else if a != nil && s.fresh(a, now) {
+			s.cache.put(key, a)
+			return a, nil
+		}
+	}
+
+	raw, err := s.origin.Fetch(ctx, title)
+	if err != nil {
+		var stdErr *stderror.WrappedError
+		if errors.As(err, &stdErr) {
+			return nil, err
+		}
+		return nil, stderror.New(stderror.ErrAPI, err)
+	}
+	html, links, err := Sanitize(raw.HTML, s.rules, s.titles)
+	if err != nil {
+		return nil, stderror.New(stderror.ErrAPI, err)
+	}
+	a := &Article{
+		Title:     s.titles.Normalize(raw.Title),
+		HTML:      html,
+		Links:     links,
+		FetchedAt: now,
+	}
+
+	// cache under the requested title and, for redirects, the canonical one
+	s.cache.put(key, a)
+	s.cache.put(s.cacheKey(a.Title), a)
+	if s.store != nil {
+		if err = s.store.Put(key, a); err != nil {
+			logger.Warnf("error caching article %q: %v", title, err)
+		}
+	}
+	return a, nil
+}
+
+// Canonicalize returns the canonical title of an article: normalized and with redirects resolved.
+// If the resolver fails, the normalized title is returned so the game keeps working.
+func (s *Service) Canonicalize(ctx context.Context, title string) string {
+	title = s.titles.Normalize(title)
+	if title == "" || s.resolver == nil {
+		return title
+	}
+	resolved, err := s.resolver.Resolve(ctx, title)
+	if err != nil {
+		logger.Warnf("error resolving title %q: %v", title, err)
+		return title
+	}
+	return s.titles.Normalize(resolved)
+}
+
+// Lookup describes the page a title refers to, see Resolver.Lookup
+func (s *Service) Lookup(ctx context.Context, title string) (*Page, error) {
+	if s.resolver == nil {
+		return nil, errors.New("no resolver configured")
+	}
+	return s.resolver.Lookup(ctx, s.titles.Normalize(title))
+}
+
+// Random returns the title of a random article
+func (s *Service) Random(ctx context.Context) (string, error) {
+	title, err := s.origin.Random(ctx)
+	if err != nil {
+		var stdErr *stderror.WrappedError
+		if errors.As(err, &stdErr) {
+			return "", err
+		}
+		return "", stderror.New(stderror.ErrAPI, err)
+	}
+	return s.titles.Normalize(title), nil
+}
+
+// CategoryMembers returns up to limit articles of a category, e.g. "Physics" or "Category:Physics"
+func (s *Service) CategoryMembers(ctx context.Context, category string, limit int) ([]string, error) {
+	category = s.titles.Normalize(strings.TrimPrefix(s.titles.Normalize(category), "Category:"))
+	if category == "" {
+		return nil, stderror.New(stderror.ErrBadRequest, errors.New("category is required"))
+	}
+	titles, err := s.origin.CategoryMembers(ctx, category, limit)
+	if err != nil {
+		var stdErr *stderror.WrappedError
+		if errors.As(err, &stdErr) {
+			return nil, err
+		}
+		return nil, stderror.New(stderror.ErrAPI, err)
+	}
+	for i, title := range titles {
+		titles[i] = s.titles.Normalize(title)
+	}
+	return titles, nil
+}
+
+// fresh reports whether a cached article can still be served
+func (s *Service) fresh(a *Article, now time.Time) bool {
+	return s.ttl <= 0 || now.Sub(a.FetchedAt) < s.ttl
+}
@@ -0,0 +1,51 @@
+package wiki
+
+import (
+	"errors"
+	"sort"
+	"wikirace/pkg/stderror"
+)
+
+// Wikis holds a Service for every language the server supports
+type Wikis struct {
+	defaultLanguage string
+	services        map[string]*Service
+}
+
+// NewWikis creates a Wikis serving the given services, keyed by language,
+// games without a language use defaultLanguage
+func NewWikis(defaultLanguage string, services map[string]*Service) *Wikis {
+	return &Wikis{defaultLanguage: defaultLanguage, services: services}
+}
+
+// Service returns the Service of a language, an empty language selects the default language
+func (w *Wikis) Service(language string) (*Service, error) {
+	if language == "" {
+		language = w.defaultLanguage
+	}
+	s, ok := w.services[language]
+	if !ok {
+		return nil, stderror.New(stderror.ErrValidation, errors.New("unsupported language: "+language))
+	}
+	return s, nil
+}
+
+// Default returns the Service of the default language
+func (w *Wikis) Default() *Service {
+	return w.services[w.defaultLanguage]
+}
+
+// DefaultLanguage returns the language of games that do not choose one
+func (w *Wikis) DefaultLanguage() string {
+	return w.defaultLanguage
+}
+
+// Languages returns the supported languages in alphabetical order
+func (w *Wikis) Languages() []string {
+	languages := make([]string, 0, len(w.services))
+	for language := range w.services {
+		languages = append(languages, language)
+	}
+	sort.Strings(languages)
+	return languages
+}
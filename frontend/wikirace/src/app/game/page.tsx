@@ -7,6 +7,8 @@ import {
   resetGame,
   addPath,
   leaveGame,
+  getHints,
+  getHintProviders,
 } from "@/services/gameService";
 import type { Game } from "@/types/game";
 import styles from './game.module.css';
@@ -55,18 +57,26 @@ export default function Game() {
   const [hintedLinks, setHintedLinks] = useState<HintedLink[]>([]);
   const [gptHints, setGptHints] = useState<GPTHint[]>([]);
   const [isLoadingGPTHint, setIsLoadingGPTHint] = useState(false);
+  const [hintProviders, setHintProviders] = useState<string[]>([]);
 
   const articleContainerRef = useRef<HTMLDivElement>(null);
 
   const handleReset = async () => {
     try {
-      await resetGame(gameCode);
+      await resetGame(gameCode, localStorage.getItem("playerId") || "");
     } catch (error) {
       console.error("Failed to reset game:", error);
       setError("Failed to reset game");
     }
   };
 
+  useEffect(() => {
+    // only offer the hints the server has enabled
+    getHintProviders()
+      .then(setHintProviders)
+      .catch((error) => console.error("Failed to get hint providers:", error));
+  }, []);
+
   useEffect(() => {
     const loadInitialArticle = async () => {
       if (!currentArticle || content) return; // Skip if we already have content
@@ -100,7 +110,10 @@ export default function Game() {
       }
 
       try {
-        const gameData = await getGameInfo(gameCode);
+        const gameData = await getGameInfo(
+          gameCode,
+          localStorage.getItem("playerId") || ""
+        );
         
         // Only update if the state has changed
         setGame(prevGame => {
@@ -199,7 +212,7 @@ export default function Game() {
           )
           .map(link => link) // Create new array to avoid modifying original
       )
-    ).slice(6, 12); // Start from index 4 (5th element) and get up to 6 links
+    ).slice(6, 12); // Skip the first 6 links and score up to 6 more, fewer than MAX_HINT_LIMIT so every one gets a score
 
     const linkTitles = linksForHints.map((link) =>
       normalizeWikiLink(link.getAttribute("href") || "")
@@ -208,24 +221,18 @@ export default function Game() {
     if (linkTitles.length === 0) return;
 
     try {
-      const response = await fetch("/game/embedding-hints", {
-        method: "POST",
-        body: JSON.stringify({
-          links: linkTitles,
-          target: targetArticle,
-        }),
-      });
-
-      if (!response.ok) {
-        throw new Error("Failed to get hint from API");
-      }
-
-      const data = await response.json();
-      const { similarities } = data;
+      const suggestions = await getHints(
+        gameCode,
+        localStorage.getItem("playerId") || "",
+        // without embeddings the server's default provider scores the links
+        hintProviders.includes("embedding") ? "embedding" : "",
+        linkTitles,
+        linkTitles.length
+      );
 
       const newHintedLinks: HintedLink[] = [];
 
-      similarities.forEach(({ link, similarity }: any) => {
+      suggestions.forEach(({ link, score: similarity }) => {
         const matchingLink = linksForHints.find(
           (l) => normalizeWikiLink(l.getAttribute("href") || "") === link
         );
@@ -269,24 +276,21 @@ export default function Game() {
     );
 
     try {
-      const response = await fetch("/game/gpt-hints", {
-        method: "POST",
-        body: JSON.stringify({
-          links: linkTitles,
-          currentArticle,
-          target: targetArticle,
-        }),
-      });
-
-      if (!response.ok) {
-        throw new Error("Failed to get GPT hint");
-      }
-
-      const data = await response.json();
-      setGptHints(data.suggestions);
+      const suggestions = await getHints(
+        gameCode,
+        localStorage.getItem("playerId") || "",
+        "llm",
+        linkTitles,
+        5
+      );
+      const hints: GPTHint[] = suggestions.map(({ link, reasoning }) => ({
+        link,
+        reasoning: reasoning || "",
+      }));
+      setGptHints(hints);
 
       // Highlight the suggested links
-      data.suggestions.forEach((hint: GPTHint) => {
+      hints.forEach((hint: GPTHint) => {
         const matchingLinks = links.filter(
           (link) =>
             normalizeWikiLink(link.getAttribute("href") || "") === hint.link
@@ -370,21 +374,23 @@ export default function Game() {
           </div>
 
           <div className="mt-6 space-y-3">
-            <button
-              onClick={handleGPTHint}
-              disabled={isLoadingGPTHint}
-              className="w-full py-3 px-4 bg-gradient-to-r from-purple-500 to-purple-600 hover:from-purple-600 hover:to-purple-700 text-white rounded-lg transition-all duration-200 flex items-center justify-center gap-2 shadow-md hover:shadow-lg transform hover:-translate-y-0.5 disabled:opacity-50"
-            >
-              <span className="material-icons-outlined">psychology</span>
-              {isLoadingGPTHint ? "Thinking..." : "Get Help from ChatGPT"}
-            </button>
+            {hintProviders.includes("llm") && (
+              <button
+                onClick={handleGPTHint}
+                disabled={isLoadingGPTHint}
+                className="w-full py-3 px-4 bg-gradient-to-r from-purple-500 to-purple-600 hover:from-purple-600 hover:to-purple-700 text-white rounded-lg transition-all duration-200 flex items-center justify-center gap-2 shadow-md hover:shadow-lg transform hover:-translate-y-0.5 disabled:opacity-50"
+              >
+                <span className="material-icons-outlined">psychology</span>
+                {isLoadingGPTHint ? "Thinking..." : "Get Help from ChatGPT"}
+              </button>
+            )}
 
             <button
               onClick={handleHintClick}
               className="w-full py-3 px-4 bg-gradient-to-r from-blue-500 to-blue-600 hover:from-blue-600 hover:to-blue-700 text-white rounded-lg transition-all duration-200 flex items-center justify-center gap-2 shadow-md hover:shadow-lg transform hover:-translate-y-0.5"
             >
               <span className="material-icons-outlined">lightbulb</span>
-              Get Embeddings Similarity
+              {hintProviders.includes("embedding") ? "Get Embeddings Similarity" : "Get Link Scores"}
             </button>
 
             <button
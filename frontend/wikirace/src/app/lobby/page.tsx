@@ -162,7 +162,7 @@ export default function Lobby() {
           ]);
           setStartArticle(randomStart);
           setTargetArticle(randomTarget);
-          await updateGame(gameCode, randomStart, randomTarget);
+          await updateGame(gameCode, playerId, randomStart, randomTarget);
         } catch (error) {
           console.error("Failed to get random articles:", error);
         }
@@ -170,7 +170,7 @@ export default function Lobby() {
     };
 
     initializeRandomArticles();
-  }, [isLeader, gameCode]);
+  }, [isLeader, gameCode, playerId]);
 
   const handleStartGame = async () => {
     if (!startArticle || !targetArticle) {
@@ -179,7 +179,7 @@ export default function Lobby() {
     }
 
     try {
-      await startGame(gameCode, startArticle, targetArticle);
+      await startGame(gameCode, playerId, startArticle, targetArticle);
       router.push(
         `/game?start=${startArticle}&target=${targetArticle}&code=${gameCode}`
       );
@@ -218,7 +218,7 @@ export default function Lobby() {
     setShowStartSuggestions(false);
     if (isLeader) {
       try {
-        await updateGame(gameCode, article, targetArticle);
+        await updateGame(gameCode, playerId, article, targetArticle);
       } catch (error) {
         console.error("Failed to update game:", error);
       }
@@ -230,7 +230,7 @@ export default function Lobby() {
     setShowTargetSuggestions(false);
     if (isLeader) {
       try {
-        await updateGame(gameCode, startArticle, article);
+        await updateGame(gameCode, playerId, startArticle, article);
       } catch (error) {
         console.error("Failed to update game:", error);
       }
@@ -243,7 +243,7 @@ export default function Lobby() {
     try {
       const randomArticle = await getRandomWikipediaArticle();
       setStartArticle(randomArticle);
-      await updateGame(gameCode, randomArticle, targetArticle);
+      await updateGame(gameCode, playerId, randomArticle, targetArticle);
     } catch (error) {
       console.error("Failed to get random start article:", error);
     }
@@ -254,7 +254,7 @@ export default function Lobby() {
     try {
       const randomArticle = await getRandomWikipediaArticle();
       setTargetArticle(randomArticle);
-      await updateGame(gameCode, startArticle, randomArticle);
+      await updateGame(gameCode, playerId, startArticle, randomArticle);
     } catch (error) {
       console.error("Failed to get random target article:", error);
     }
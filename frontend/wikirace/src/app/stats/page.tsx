@@ -35,7 +35,7 @@ export default function Stats() {
 
   const handleReset = async () => {
     try {
-      await resetGame(gameCode);
+      await resetGame(gameCode, playerId);
     } catch (error) {
       console.error("Failed to reset game:", error);
       setError("Failed to reset game");
@@ -66,6 +66,11 @@ export default function Stats() {
             <div key={player.id} className="border rounded p-4">
               <h4 className="font-semibold mb-2">
                 {player.name} {player.isWinner && "🏆"}
+                {!!player.hintsUsed && (
+                  <span className="ml-2 text-sm font-normal text-gray-500">
+                    💡 {player.hintsUsed} {player.hintsUsed === 1 ? "hint" : "hints"}
+                  </span>
+                )}
               </h4>
               <div className="space-y-1">
                 {player.paths.map((path, index) => (
@@ -2,8 +2,10 @@ import { Game } from '@/types/game';
 
 const API_BASE_URL = process.env.NEXT_PUBLIC_API_BASE_URL;
 
-interface HintResponse {
-  data: Array<{ link: string; similarity: number }>;
+export interface HintSuggestion {
+  link: string;
+  score: number;
+  reasoning?: string;
 }
 
 export async function createGame(leaderName: string, playerId: string): Promise<Game> {
@@ -49,8 +51,8 @@ export async function joinGame(playerName: string, playerId: string, gameCode: s
   return data.data;
 }
 
-export async function getGameInfo(gameCode: string): Promise<Game> {
-  const response = await fetch(`${API_BASE_URL}/api/v1/games/info?gameCode=${gameCode}`, {
+export async function getGameInfo(gameCode: string, playerId: string = ''): Promise<Game> {
+  const response = await fetch(`${API_BASE_URL}/api/v1/games/info?gameCode=${gameCode}&playerID=${encodeURIComponent(playerId)}`, {
     method: 'GET',
     headers: {
       'Content-Type': 'application/json',
@@ -65,7 +67,7 @@ export async function getGameInfo(gameCode: string): Promise<Game> {
   return data.data;
 }
 
-export async function startGame(gameCode: string, startArticle: string, targetArticle: string): Promise<Game> {
+export async function startGame(gameCode: string, playerId: string, startArticle: string, targetArticle: string): Promise<Game> {
   const response = await fetch(`${API_BASE_URL}/api/v1/games/start`, {
     method: 'POST',
     headers: {
@@ -73,6 +75,7 @@ export async function startGame(gameCode: string, startArticle: string, targetAr
     },
     body: JSON.stringify({
       gameCode,
+      playerID: playerId,
       startArticle,
       targetArticle,
     }),
@@ -127,7 +130,7 @@ export async function addPath(gameCode: string, playerId: string, articleName: s
   return data.data;
 }
 
-export async function resetGame(gameCode: string): Promise<Game> {
+export async function resetGame(gameCode: string, playerId: string): Promise<Game> {
   const response = await fetch(`${API_BASE_URL}/api/v1/games/reset`, {
     method: 'POST',
     headers: {
@@ -135,6 +138,7 @@ export async function resetGame(gameCode: string): Promise<Game> {
     },
     body: JSON.stringify({
       gameCode,
+      playerID: playerId,
     }),
   });
 
@@ -147,7 +151,7 @@ export async function resetGame(gameCode: string): Promise<Game> {
   return data.data;
 }
 
-export async function updateGame(gameCode: string, startArticle: string, targetArticle: string): Promise<Game> {
+export async function updateGame(gameCode: string, playerId: string, startArticle: string, targetArticle: string): Promise<Game> {
   const response = await fetch(`${API_BASE_URL}/api/v1/games/update`, {
     method: 'POST',
     headers: {
@@ -155,6 +159,7 @@ export async function updateGame(gameCode: string, startArticle: string, targetA
     },
     body: JSON.stringify({
       gameCode,
+      playerID: playerId,
       startArticle,
       targetArticle,
     }),
@@ -167,4 +172,53 @@ export async function updateGame(gameCode: string, startArticle: string, targetA
 
   const data = await response.json();
   return data.data;
-} 
\ No newline at end of file
+} 
+
+// MAX_HINT_LIMIT is the most suggestions the server returns for one hint request
+export const MAX_HINT_LIMIT = 20;
+
+// getHintProviders returns the hint providers the server has enabled, e.g. 'lexical', 'embedding' and 'llm'
+export async function getHintProviders(): Promise<string[]> {
+  const response = await fetch(`${API_BASE_URL}/api/v1/hints/providers`);
+
+  if (!response.ok) {
+    throw new Error('Failed to get hint providers');
+  }
+
+  const data = await response.json();
+  return data.data;
+}
+
+// getHints asks the server to rank the links of the player's current article, every call uses one hint of the budget.
+// An empty provider uses the server's default provider.
+export async function getHints(
+  gameCode: string,
+  playerId: string,
+  provider: string,
+  links: string[],
+  limit: number
+): Promise<HintSuggestion[]> {
+  const response = await fetch(`${API_BASE_URL}/api/v1/hints`, {
+    method: 'POST',
+    headers: {
+      'Content-Type': 'application/json',
+    },
+    body: JSON.stringify({
+      gameCode,
+      playerID: playerId,
+      provider,
+      links,
+      limit: Math.min(limit, MAX_HINT_LIMIT),
+    }),
+  });
+
+  if (!response.ok) {
+    throw new Error('Failed to get hints');
+  }
+
+  const data = await response.json();
+  if (data.code !== 0) {
+    throw new Error(data.msg || 'Failed to get hints');
+  }
+  return data.data.suggestions;
+}
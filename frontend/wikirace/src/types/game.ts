@@ -1,14 +1,33 @@
 export interface Player {
   id: string;
+  accountID?: string;
   name: string;
   isLeader: boolean;
   isWinner: boolean;
   paths: string[];
+  pathTimes?: string[];
+  clicks?: number;
+  hintsUsed?: number;
+  bot?: {
+    strategy: string;
+    delay: number;
+  };
+  rating?: number;
 }
 
 export interface Game {
   code: string;
+  type?: 'multiplayer' | 'solo';
+  public?: boolean;
+  maxPlayers?: number;
+  midGameJoin?: 'reject' | 'spectate' | 'late';
+  protected?: boolean;
+  livePaths?: boolean;
+  hintBudget?: number;
+  hintPenalty?: number;
+  language?: string;
   players: Player[];
+  spectators?: Player[];
   state: 'waiting' | 'playing' | 'finished';
   startArticle: string;
   targetArticle: string;
@@ -25,6 +44,7 @@ export interface JoinGameRequest {
   playerName: string;
   playerID: string;
   gameCode: string;
+  spectator?: boolean;
 }
 
 export interface GameResponse {